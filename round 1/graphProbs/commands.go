@@ -0,0 +1,193 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"graphProbs/graphProbs"
+	"sort"
+	"strings"
+)
+
+// result is the answer to a single query, it is printed either as text lines
+// or marshalled as JSON.
+type result interface {
+	textLines() []string
+}
+
+type solver func(g *graphProbs.StringGraph, q graphProbs.Query) (result, error)
+
+type command struct {
+	name        string
+	description string
+	// setup registers the command's own flags, the returned func validates them
+	// once parsed and reports whether the input has weighted edges together
+	// with the solver to run on every query.
+	setup func(fs *flag.FlagSet) func() (bool, solver, error)
+}
+
+type reachResult struct {
+	Follower  graphProbs.Node `json:"follower"`
+	Following graphProbs.Node `json:"following"`
+	Reachable bool            `json:"reachable"`
+}
+
+func (r *reachResult) textLines() []string {
+	if r.Reachable {
+		return []string{"1"}
+	}
+	return []string{"0"}
+}
+
+func solveFindReachability(g *graphProbs.StringGraph, q graphProbs.Query) (result, error) {
+	return &reachResult{
+		Follower:  q.Follower,
+		Following: q.Following,
+		Reachable: g.CanReach(q.Follower, q.Following),
+	}, nil
+}
+
+type shortestResult struct {
+	Follower  graphProbs.Node    `json:"follower"`
+	Following graphProbs.Node    `json:"following"`
+	Time      *graphProbs.Weight `json:"time"`
+	Path      []graphProbs.Node  `json:"path,omitempty"`
+}
+
+func (r *shortestResult) textLines() []string {
+	if r.Time == nil {
+		return []string{"nil"}
+	}
+	lines := []string{fmt.Sprint(*r.Time)}
+	if r.Path != nil {
+		lines = append(lines, strings.Join(r.Path, " -> "))
+	}
+	return lines
+}
+
+func solveShortestTime(printPath bool) solver {
+	return func(g *graphProbs.StringGraph, q graphProbs.Query) (result, error) {
+		res := &shortestResult{Follower: q.Follower, Following: q.Following}
+		shortestPath := g.ShortestPath(q.Follower, q.Following)
+		if shortestPath == nil {
+			return res, nil
+		}
+		res.Time = &shortestPath.Wt
+		if printPath {
+			res.Path = shortestPath.Nodes()
+		}
+		return res, nil
+	}
+}
+
+type blockResult struct {
+	Follower  graphProbs.Node   `json:"follower"`
+	Following graphProbs.Node   `json:"following"`
+	Nodes     []graphProbs.Node `json:"nodes"`
+}
+
+func (r *blockResult) textLines() []string {
+	return r.Nodes
+}
+
+func solveMinimumNeighborsToBlockToEnsureUnreachability(useMinimumCut bool) solver {
+	return func(g *graphProbs.StringGraph, q graphProbs.Query) (result, error) {
+		res := &blockResult{Follower: q.Follower, Following: q.Following, Nodes: []graphProbs.Node{}}
+		if useMinimumCut {
+			cut, err := g.MinimumVertexCut(q.Follower, q.Following)
+			if err != nil {
+				return nil, err
+			}
+			res.Nodes = cut
+			return res, nil
+		}
+		g.WalkNeighborsToBlockToEnsureUnreachability(q.Follower, q.Following, func(neighbor graphProbs.Node) bool {
+			res.Nodes = append(res.Nodes, neighbor)
+			return true
+		})
+		sort.Strings(res.Nodes)
+		return res, nil
+	}
+}
+
+type edgeResult struct {
+	Frm graphProbs.Node   `json:"from"`
+	To  graphProbs.Node   `json:"to"`
+	Wt  graphProbs.Weight `json:"weight"`
+}
+
+type edgeCutResult struct {
+	Follower  graphProbs.Node `json:"follower"`
+	Following graphProbs.Node `json:"following"`
+	Edges     []edgeResult    `json:"edges"`
+	weighted  bool
+}
+
+func (r *edgeCutResult) textLines() []string {
+	lines := make([]string, len(r.Edges))
+	for i, edge := range r.Edges {
+		if r.weighted {
+			lines[i] = fmt.Sprint(edge.Frm, " ", edge.To, " ", edge.Wt)
+		} else {
+			lines[i] = fmt.Sprint(edge.Frm, " ", edge.To)
+		}
+	}
+	return lines
+}
+
+func solveMinimumEdgeCut(weighted bool) solver {
+	return func(g *graphProbs.StringGraph, q graphProbs.Query) (result, error) {
+		minimumEdgeCut := g.MinimumEdgeCut
+		if weighted {
+			minimumEdgeCut = g.MinimumWeightedEdgeCut
+		}
+		cut, err := minimumEdgeCut(q.Follower, q.Following)
+		if err != nil {
+			return nil, err
+		}
+		res := &edgeCutResult{Follower: q.Follower, Following: q.Following, Edges: make([]edgeResult, len(cut)), weighted: weighted}
+		for i, edge := range cut {
+			res.Edges[i] = edgeResult{Frm: edge.Frm, To: edge.To, Wt: edge.Wt}
+		}
+		return res, nil
+	}
+}
+
+var commands = []command{
+	{
+		name:        "reach",
+		description: "print 1 if follower can reach following, 0 otherwise",
+		setup: func(fs *flag.FlagSet) func() (bool, solver, error) {
+			return func() (bool, solver, error) { return false, solveFindReachability, nil }
+		},
+	},
+	{
+		name:        "shortest",
+		description: "print the shortest time from follower to following over weighted edges",
+		setup: func(fs *flag.FlagSet) func() (bool, solver, error) {
+			printPath := fs.Bool("path", false, "also print the nodes along the shortest path")
+			return func() (bool, solver, error) { return true, solveShortestTime(*printPath), nil }
+		},
+	},
+	{
+		name:        "block",
+		description: "print the nodes to block so that follower can no longer reach following",
+		setup: func(fs *flag.FlagSet) func() (bool, solver, error) {
+			mode := fs.String("mode", "parents", "parents: the reachable immediate parents of following, mincut: a minimum vertex cut")
+			return func() (bool, solver, error) {
+				if *mode != "parents" && *mode != "mincut" {
+					return false, nil, errors.New(fmt.Sprintf("unknown mode: %s", *mode))
+				}
+				return false, solveMinimumNeighborsToBlockToEnsureUnreachability(*mode == "mincut"), nil
+			}
+		},
+	},
+	{
+		name:        "edgecut",
+		description: "print a minimum set of edges to remove so that follower can no longer reach following",
+		setup: func(fs *flag.FlagSet) func() (bool, solver, error) {
+			weighted := fs.Bool("weighted", false, "read weighted edges and minimise the total weight removed")
+			return func() (bool, solver, error) { return *weighted, solveMinimumEdgeCut(*weighted), nil }
+		},
+	},
+}
@@ -0,0 +1,135 @@
+package dinic
+
+import "math"
+
+const InfiniteCapacity uint64 = math.MaxUint64 / 2
+
+type ArcId = int
+
+// Network is an integer indexed residual network solved with Dinic's
+// algorithm. Arcs are stored in pairs, so arc^1 is the reverse of arc.
+type Network struct {
+	adjacency [][]ArcId
+	frm       []int
+	to        []int
+	capacity  []uint64
+	residual  []uint64
+	level     []int
+	iter      []int
+}
+
+func New(numVertices int) *Network {
+	return &Network{adjacency: make([][]ArcId, numVertices)}
+}
+
+func (f *Network) NumVertices() int {
+	return len(f.adjacency)
+}
+
+func (f *Network) AddArc(u int, v int, capacity uint64) ArcId {
+	arc := len(f.to)
+	f.adjacency[u] = append(f.adjacency[u], arc)
+	f.frm = append(f.frm, u)
+	f.to = append(f.to, v)
+	f.capacity = append(f.capacity, capacity)
+	f.residual = append(f.residual, capacity)
+	f.adjacency[v] = append(f.adjacency[v], arc+1)
+	f.frm = append(f.frm, v)
+	f.to = append(f.to, u)
+	f.capacity = append(f.capacity, 0)
+	f.residual = append(f.residual, 0)
+	return arc
+}
+
+func (f *Network) Capacity(arc ArcId) uint64 {
+	return f.capacity[arc]
+}
+
+func (f *Network) Residual(arc ArcId) uint64 {
+	return f.residual[arc]
+}
+
+// Flow is the amount pushed along a forward arc returned by AddArc.
+func (f *Network) Flow(arc ArcId) uint64 {
+	return f.capacity[arc] - f.residual[arc]
+}
+
+func (f *Network) buildLevels(s int, t int) bool {
+	f.level = make([]int, len(f.adjacency))
+	for i := range f.level {
+		f.level[i] = -1
+	}
+	f.level[s] = 0
+	queue := []int{s}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, arc := range f.adjacency[u] {
+			v := f.to[arc]
+			if f.residual[arc] > 0 && f.level[v] < 0 {
+				f.level[v] = f.level[u] + 1
+				queue = append(queue, v)
+			}
+		}
+	}
+	return f.level[t] >= 0
+}
+
+func (f *Network) augment(u int, t int, limit uint64) uint64 {
+	if u == t {
+		return limit
+	}
+	for ; f.iter[u] < len(f.adjacency[u]); f.iter[u]++ {
+		arc := f.adjacency[u][f.iter[u]]
+		v := f.to[arc]
+		if f.residual[arc] == 0 || f.level[v] != f.level[u]+1 {
+			continue
+		}
+		pushLimit := limit
+		if f.residual[arc] < pushLimit {
+			pushLimit = f.residual[arc]
+		}
+		pushed := f.augment(v, t, pushLimit)
+		if pushed > 0 {
+			f.residual[arc] -= pushed
+			f.residual[arc^1] += pushed
+			return pushed
+		}
+	}
+	return 0
+}
+
+func (f *Network) MaxFlow(s int, t int) uint64 {
+	total := uint64(0)
+	for f.buildLevels(s, t) {
+		f.iter = make([]int, len(f.adjacency))
+		for {
+			pushed := f.augment(s, t, InfiniteCapacity)
+			if pushed == 0 {
+				break
+			}
+			total += pushed
+		}
+	}
+	return total
+}
+
+// SourceSide marks the vertices still reachable from s in the residual network,
+// after MaxFlow these form the source side of a minimum cut.
+func (f *Network) SourceSide(s int) []bool {
+	reached := make([]bool, len(f.adjacency))
+	reached[s] = true
+	stack := []int{s}
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, arc := range f.adjacency[u] {
+			v := f.to[arc]
+			if f.residual[arc] > 0 && !reached[v] {
+				reached[v] = true
+				stack = append(stack, v)
+			}
+		}
+	}
+	return reached
+}
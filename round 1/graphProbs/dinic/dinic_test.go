@@ -0,0 +1,51 @@
+package dinic
+
+import "testing"
+
+func TestMaxFlow(t *testing.T) {
+	// 0 -> 1 -> 3 and 0 -> 2 -> 3, with 1 -> 2 letting extra flow cross over
+	network := New(4)
+	a := network.AddArc(0, 1, 3)
+	b := network.AddArc(0, 2, 2)
+	c := network.AddArc(1, 2, 5)
+	d := network.AddArc(1, 3, 2)
+	e := network.AddArc(2, 3, 3)
+	if network.NumVertices() != 4 {
+		t.Errorf("expected 4 vertices, got %d", network.NumVertices())
+	}
+	value := network.MaxFlow(0, 3)
+	if value != 5 {
+		t.Fatalf("expected a maximum flow of 5, got %d", value)
+	}
+	for _, arc := range []ArcId{a, b, c, d, e} {
+		if network.Flow(arc)+network.Residual(arc) != network.Capacity(arc) {
+			t.Errorf("arc %d: flow %d and residual %d do not add up to capacity %d", arc, network.Flow(arc), network.Residual(arc), network.Capacity(arc))
+		}
+		if network.Residual(arc^1) != network.Flow(arc) {
+			t.Errorf("arc %d: expected the reverse arc to hold the flow %d, got %d", arc, network.Flow(arc), network.Residual(arc^1))
+		}
+	}
+	if network.Flow(a)+network.Flow(b) != 5 || network.Flow(d)+network.Flow(e) != 5 {
+		t.Errorf("expected 5 units to leave 0 and enter 3")
+	}
+	if network.Flow(a) != network.Flow(c)+network.Flow(d) {
+		t.Errorf("expected flow to be conserved at 1")
+	}
+
+	reached := network.SourceSide(0)
+	if !reached[0] || reached[3] {
+		t.Errorf("expected 0 and not 3 on the source side, got %v", reached)
+	}
+}
+
+func TestDisconnected(t *testing.T) {
+	network := New(3)
+	network.AddArc(0, 1, 4)
+	if network.MaxFlow(0, 2) != 0 {
+		t.Error("expected no flow to an unreachable vertex")
+	}
+	reached := network.SourceSide(0)
+	if !reached[0] || !reached[1] || reached[2] {
+		t.Errorf("expected 0 and 1 on the source side, got %v", reached)
+	}
+}
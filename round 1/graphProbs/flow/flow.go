@@ -0,0 +1,186 @@
+package flow
+
+import (
+	"fmt"
+	"graphProbs/dinic"
+	"graphProbs/graphProbs"
+	"sort"
+)
+
+type Capacity = uint64
+
+// Result holds a maximum flow from Source to Sink over a graph whose edge
+// weights are read as capacities. Each edge of an undirected graph can carry
+// up to its capacity in either direction.
+type Result[N graphProbs.Ordered, W graphProbs.Number] struct {
+	Source N
+	Sink   N
+	Value  Capacity
+
+	edges    []graphProbs.Edge[N, W]
+	arcs     []dinic.ArcId
+	nodes    []N
+	indices  map[N]int
+	network  *dinic.Network
+	reached  []bool
+	edgeArcs map[[2]N][]dinic.ArcId
+	capacity func(graphProbs.Edge[N, W]) Capacity
+}
+
+// MaxFlow reads edge weights as capacities and returns the error of
+// Graph.CheckWeightsAreCapacities if one is negative or not a whole number,
+// MaxFlowWithCapacity can round fractional weights as needed.
+func MaxFlow[N graphProbs.Ordered, W graphProbs.Number](g *graphProbs.Graph[N, W], source N, sink N) (*Result[N, W], error) {
+	err := g.CheckWeightsAreCapacities()
+	if err != nil {
+		return nil, err
+	}
+	return MaxFlowWithCapacity(g, source, sink, func(e graphProbs.Edge[N, W]) Capacity { return Capacity(e.Wt) })
+}
+
+// UnitMaxFlow gives every edge a capacity of 1, so its value is the number of
+// edge disjoint paths from source to sink.
+func UnitMaxFlow[N graphProbs.Ordered, W graphProbs.Number](g *graphProbs.Graph[N, W], source N, sink N) (*Result[N, W], error) {
+	return MaxFlowWithCapacity(g, source, sink, func(graphProbs.Edge[N, W]) Capacity { return 1 })
+}
+
+func MaxFlowWithCapacity[N graphProbs.Ordered, W graphProbs.Number](g *graphProbs.Graph[N, W], source N, sink N, capacity func(graphProbs.Edge[N, W]) Capacity) (*Result[N, W], error) {
+	for _, node := range []N{source, sink} {
+		if !g.IsValidNode(node) {
+			return nil, &graphProbs.NodeNotFoundError[N]{Node: node}
+		}
+	}
+	if source == sink {
+		return nil, &graphProbs.SameEndpointsError[N]{Node: source}
+	}
+
+	nodes := g.Nodes()
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+	indices := make(map[N]int, len(nodes))
+	for i, node := range nodes {
+		indices[node] = i
+	}
+	edges := g.Arcs()
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Frm != edges[j].Frm {
+			return edges[i].Frm < edges[j].Frm
+		}
+		if edges[i].To != edges[j].To {
+			return edges[i].To < edges[j].To
+		}
+		return edges[i].Id < edges[j].Id
+	})
+
+	network := dinic.New(len(nodes))
+	arcs := make([]dinic.ArcId, len(edges))
+	edgeArcs := make(map[[2]N][]dinic.ArcId, len(edges))
+	for i, edge := range edges {
+		arcs[i] = network.AddArc(indices[edge.Frm], indices[edge.To], capacity(edge))
+		key := [2]N{edge.Frm, edge.To}
+		edgeArcs[key] = append(edgeArcs[key], arcs[i])
+	}
+	value := network.MaxFlow(indices[source], indices[sink])
+
+	return &Result[N, W]{
+		Source:   source,
+		Sink:     sink,
+		Value:    value,
+		edges:    edges,
+		arcs:     arcs,
+		nodes:    nodes,
+		indices:  indices,
+		network:  network,
+		reached:  network.SourceSide(indices[source]),
+		edgeArcs: edgeArcs,
+		capacity: capacity,
+	}, nil
+}
+
+// Flow returns the flow from frm to to, summed over the parallel edges of a
+// multigraph.
+func (r *Result[N, W]) Flow(frm N, to N) (Capacity, error) {
+	arcs, ok := r.edgeArcs[[2]N{frm, to}]
+	if !ok {
+		return 0, fmt.Errorf("no edge %v -> %v in the flow network", frm, to)
+	}
+	flow := Capacity(0)
+	for _, arc := range arcs {
+		flow += r.network.Flow(arc)
+	}
+	return flow, nil
+}
+
+// EdgeFlows returns every edge carrying flow, with Wt set to the flow on it
+// and the Id of the edge kept.
+func (r *Result[N, W]) EdgeFlows() []graphProbs.Edge[N, W] {
+	flows := []graphProbs.Edge[N, W]{}
+	for i, edge := range r.edges {
+		flow := r.network.Flow(r.arcs[i])
+		if flow > 0 {
+			edge.Wt = W(flow)
+			flows = append(flows, edge)
+		}
+	}
+	return flows
+}
+
+// Residual builds the residual graph, an edge u -> v is present whenever more
+// flow can still be pushed from u to v, either along unused capacity of u -> v
+// or by cancelling flow on v -> u. Those can add up to more than any single
+// weight, so an error is returned when a residual capacity does not fit in W.
+func (r *Result[N, W]) Residual() (graphProbs.Graph[N, W], error) {
+	residuals := map[[2]N]Capacity{}
+	keys := [][2]N{}
+	add := func(key [2]N, residual Capacity) {
+		_, ok := residuals[key]
+		if !ok {
+			keys = append(keys, key)
+		}
+		residuals[key] += residual
+	}
+	for i, edge := range r.edges {
+		arc := r.arcs[i]
+		if r.network.Residual(arc) > 0 {
+			add([2]N{edge.Frm, edge.To}, r.network.Residual(arc))
+		}
+		if r.network.Flow(arc) > 0 {
+			add([2]N{edge.To, edge.Frm}, r.network.Flow(arc))
+		}
+	}
+	edges := make([]graphProbs.Edge[N, W], 0, len(residuals))
+	for _, key := range keys {
+		wt := W(residuals[key])
+		if wt < 0 || Capacity(wt) != residuals[key] {
+			return graphProbs.Graph[N, W]{}, fmt.Errorf("residual capacity %d of %v -> %v does not fit in %T", residuals[key], key[0], key[1], wt)
+		}
+		edges = append(edges, graphProbs.Edge[N, W]{Frm: key[0], To: key[1], Wt: wt})
+	}
+	return graphProbs.MkGraph(edges, r.nodes), nil
+}
+
+type Cut[N graphProbs.Ordered, W graphProbs.Number] struct {
+	SourceSide []N
+	SinkSide   []N
+	Edges      []graphProbs.Edge[N, W]
+	Capacity   Capacity
+}
+
+// MinCut partitions the nodes by reachability from the source in the residual
+// graph, the saturated edges crossing the partition form a minimum cut.
+func (r *Result[N, W]) MinCut() Cut[N, W] {
+	cut := Cut[N, W]{SourceSide: []N{}, SinkSide: []N{}, Edges: []graphProbs.Edge[N, W]{}}
+	for i, node := range r.nodes {
+		if r.reached[i] {
+			cut.SourceSide = append(cut.SourceSide, node)
+		} else {
+			cut.SinkSide = append(cut.SinkSide, node)
+		}
+	}
+	for _, edge := range r.edges {
+		if r.reached[r.indices[edge.Frm]] && !r.reached[r.indices[edge.To]] {
+			cut.Edges = append(cut.Edges, edge)
+			cut.Capacity += r.capacity(edge)
+		}
+	}
+	return cut
+}
@@ -0,0 +1,208 @@
+package flow
+
+import (
+	"errors"
+	"graphProbs/graphProbs"
+	"testing"
+)
+
+// clrsNetwork is the flow network of figure 26.1 in Introduction to
+// Algorithms, its maximum flow from s to t is 23.
+func clrsNetwork() graphProbs.StringGraph {
+	return graphProbs.MkGraph([]graphProbs.StringEdge{
+		{Frm: "s", To: "v1", Wt: 16},
+		{Frm: "s", To: "v2", Wt: 13},
+		{Frm: "v1", To: "v3", Wt: 12},
+		{Frm: "v2", To: "v1", Wt: 4},
+		{Frm: "v2", To: "v4", Wt: 14},
+		{Frm: "v3", To: "v2", Wt: 9},
+		{Frm: "v3", To: "t", Wt: 20},
+		{Frm: "v4", To: "v3", Wt: 7},
+		{Frm: "v4", To: "t", Wt: 4},
+	}, nil)
+}
+
+func TestMaxFlowValueAndConservation(t *testing.T) {
+	g := clrsNetwork()
+	r, err := MaxFlow(&g, "s", "t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Value != 23 {
+		t.Fatalf("expected a maximum flow of 23, got %d", r.Value)
+	}
+
+	capacities := map[[2]graphProbs.Node]graphProbs.Weight{}
+	for _, edge := range g.Edges() {
+		capacities[[2]graphProbs.Node{edge.Frm, edge.To}] = edge.Wt
+	}
+	net := map[graphProbs.Node]int{}
+	for _, edge := range r.EdgeFlows() {
+		if edge.Wt > capacities[[2]graphProbs.Node{edge.Frm, edge.To}] {
+			t.Errorf("%s -> %s carries %d, more than its capacity", edge.Frm, edge.To, edge.Wt)
+		}
+		flow, err := r.Flow(edge.Frm, edge.To)
+		if err != nil || flow != Capacity(edge.Wt) {
+			t.Errorf("%s -> %s: Flow gives %d %v, EdgeFlows %d", edge.Frm, edge.To, flow, err, edge.Wt)
+		}
+		net[edge.Frm] -= int(edge.Wt)
+		net[edge.To] += int(edge.Wt)
+	}
+	for _, node := range g.Nodes() {
+		expected := 0
+		switch node {
+		case "s":
+			expected = -23
+		case "t":
+			expected = 23
+		}
+		if net[node] != expected {
+			t.Errorf("expected a net inflow of %d into %s, got %d", expected, node, net[node])
+		}
+	}
+}
+
+func TestResidual(t *testing.T) {
+	g := clrsNetwork()
+	r, err := MaxFlow(&g, "s", "t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	residual, err := r.Residual()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if residual.CanReach("s", "t") {
+		t.Error("expected no augmenting path left in the residual graph")
+	}
+	for _, edge := range g.Edges() {
+		flow, _ := r.Flow(edge.Frm, edge.To)
+		back, _ := r.Flow(edge.To, edge.Frm)
+		expected := Capacity(edge.Wt) - flow + back
+		forward := residual.ImmediateParents(edge.To)[edge.Frm]
+		if forward != (expected > 0) {
+			t.Errorf("%s -> %s: expected a residual edge %v, got %v", edge.Frm, edge.To, expected > 0, forward)
+		}
+		if flow > 0 && !residual.ImmediateParents(edge.Frm)[edge.To] {
+			t.Errorf("%s -> %s carries flow, expected a residual edge back", edge.Frm, edge.To)
+		}
+	}
+	for _, edge := range residual.Edges() {
+		forward, _ := r.Flow(edge.Frm, edge.To)
+		back, _ := r.Flow(edge.To, edge.Frm)
+		var capacity Capacity
+		for _, original := range g.Neighbors(edge.Frm) {
+			if original.To == edge.To {
+				capacity = Capacity(original.Wt)
+			}
+		}
+		if Capacity(edge.Wt) != capacity-forward+back {
+			t.Errorf("%s -> %s: expected residual capacity %d, got %d", edge.Frm, edge.To, capacity-forward+back, edge.Wt)
+		}
+	}
+}
+
+func TestResidualThatDoesNotFitInW(t *testing.T) {
+	g := graphProbs.MkUndirectedGraph([]graphProbs.Edge[string, uint8]{{Frm: "a", To: "b", Wt: 200}}, nil)
+	r, err := MaxFlow(&g, "a", "b")
+	if err != nil || r.Value != 200 {
+		t.Fatalf("expected a flow of 200, got %v %v", r, err)
+	}
+	_, err = r.Residual()
+	if err == nil {
+		t.Error("expected an error for the residual capacity 400 of b -> a")
+	}
+}
+
+func TestMinCutCapacityEqualsValue(t *testing.T) {
+	directed := clrsNetwork()
+	for _, g := range []graphProbs.StringGraph{directed, graphProbs.MkUndirectedGraph(directed.Edges(), nil)} {
+		r, err := MaxFlow(&g, "s", "t")
+		if err != nil {
+			t.Fatal(err)
+		}
+		cut := r.MinCut()
+		if cut.Capacity != r.Value {
+			t.Errorf("undirected=%v: expected the cut capacity %d to equal the flow, got %d", g.IsUndirected(), r.Value, cut.Capacity)
+		}
+		if len(cut.SourceSide)+len(cut.SinkSide) != len(g.Nodes()) || cut.SourceSide[0] != "s" {
+			t.Errorf("undirected=%v: unexpected partition %v %v", g.IsUndirected(), cut.SourceSide, cut.SinkSide)
+		}
+		for _, edge := range cut.Edges {
+			flow, _ := r.Flow(edge.Frm, edge.To)
+			if flow != Capacity(edge.Wt) {
+				t.Errorf("undirected=%v: cut edge %s -> %s is not saturated", g.IsUndirected(), edge.Frm, edge.To)
+			}
+		}
+	}
+}
+
+func TestParallelEdgesAndErrors(t *testing.T) {
+	g := graphProbs.MkMultiGraph([]graphProbs.StringEdge{
+		{Frm: "a", To: "b", Wt: 2},
+		{Frm: "a", To: "b", Wt: 3},
+	}, nil)
+	r, err := MaxFlow(&g, "a", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flow, err := r.Flow("a", "b")
+	if r.Value != 5 || err != nil || flow != 5 || len(r.EdgeFlows()) != 2 {
+		t.Errorf("expected both parallel edges to carry their capacity, got %d %d %v", r.Value, flow, r.EdgeFlows())
+	}
+	_, err = r.Flow("b", "a")
+	if err == nil {
+		t.Error("expected an error for an edge that is not in the network")
+	}
+
+	_, err = MaxFlow(&g, "a", "a")
+	var sameErr *graphProbs.SameEndpointsError[graphProbs.Node]
+	if !errors.As(err, &sameErr) {
+		t.Errorf("expected a SameEndpointsError, got %v", err)
+	}
+	signed := graphProbs.MkGraph([]graphProbs.SignedEdge{{Frm: "a", To: "b", Wt: -1}}, nil)
+	_, err = MaxFlow(&signed, "a", "b")
+	var negativeErr *graphProbs.NegativeWeightError[graphProbs.Node, graphProbs.SignedWeight]
+	if !errors.As(err, &negativeErr) {
+		t.Errorf("expected a NegativeWeightError, got %v", err)
+	}
+	unit, err := UnitMaxFlow(&signed, "a", "b")
+	if err != nil || unit.Value != 1 {
+		t.Errorf("expected the unit flow to ignore weights, got %v %v", unit, err)
+	}
+}
+
+func TestFractionalCapacities(t *testing.T) {
+	g := graphProbs.MkGraph([]graphProbs.Edge[graphProbs.Node, float64]{
+		{Frm: "s", To: "a", Wt: 1},
+		{Frm: "a", To: "b", Wt: 0.6},
+		{Frm: "a", To: "c", Wt: 0.6},
+		{Frm: "b", To: "t", Wt: 10},
+		{Frm: "c", To: "t", Wt: 10},
+	}, nil)
+	_, err := MaxFlow(&g, "s", "t")
+	var capacityErr *graphProbs.InvalidCapacityError[graphProbs.Node, float64]
+	if !errors.As(err, &capacityErr) || capacityErr.Edge.Frm != "a" || capacityErr.Edge.To != "b" {
+		t.Errorf("expected an InvalidCapacityError for a -> b, got %v", err)
+	}
+	_, err = g.MinimumWeightedEdgeCut("s", "t")
+	if !errors.As(err, &capacityErr) {
+		t.Errorf("expected MinimumWeightedEdgeCut to return an InvalidCapacityError, got %v", err)
+	}
+
+	err = g.UpdateWeight("a", "b", 1)
+	if err == nil {
+		err = g.UpdateWeight("a", "c", 2)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := MaxFlow(&g, "s", "t")
+	if err != nil || r.Value != 1 {
+		t.Errorf("expected whole float weights to be read as capacities, got %v %v", r, err)
+	}
+	cut, err := g.MinimumWeightedEdgeCut("s", "t")
+	if err != nil || len(cut) != 1 || cut[0].Frm != "s" {
+		t.Errorf("expected s -> a as the cheapest cut, got %v %v", cut, err)
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"graphProbs/generators"
+	"graphProbs/graphProbs"
+	"io"
+	"math/rand"
+	"os"
+	"strconv"
+)
+
+const generateDescription = "write a synthetic graph and queries in the input format of the other commands"
+
+const models = "er, ba, grid, chain, star or dag"
+
+type generateConfig struct {
+	model      string
+	n          int
+	p          float64
+	m          int
+	rows       int
+	cols       int
+	seed       int64
+	minWeight  uint
+	maxWeight  uint
+	weighted   bool
+	queries    int
+	follower   string
+	following  string
+	outputPath string
+}
+
+func generateGraph(cfg generateConfig) (graphProbs.StringGraph, error) {
+	opts := generators.Options{Seed: cfg.seed, MinWeight: cfg.minWeight, MaxWeight: cfg.maxWeight}
+	if cfg.minWeight > cfg.maxWeight {
+		return graphProbs.StringGraph{}, errors.New(fmt.Sprintf("expected -min-weight of at most -max-weight, got %d > %d", cfg.minWeight, cfg.maxWeight))
+	}
+	if cfg.model != "grid" && cfg.n < 1 {
+		return graphProbs.StringGraph{}, errors.New("expected -n of at least 1")
+	}
+	switch cfg.model {
+	case "er":
+		if cfg.p < 0 || cfg.p > 1 {
+			return graphProbs.StringGraph{}, errors.New(fmt.Sprintf("expected -p between 0 and 1, got %v", cfg.p))
+		}
+		return generators.ErdosRenyi(cfg.n, cfg.p, opts), nil
+	case "ba":
+		if cfg.m < 1 || cfg.m >= cfg.n {
+			return graphProbs.StringGraph{}, errors.New(fmt.Sprintf("expected -m between 1 and n - 1, got %d", cfg.m))
+		}
+		return generators.BarabasiAlbert(cfg.n, cfg.m, opts), nil
+	case "grid":
+		if cfg.rows < 1 || cfg.cols < 1 {
+			return graphProbs.StringGraph{}, errors.New("expected -rows and -cols of at least 1")
+		}
+		return generators.Grid(cfg.rows, cfg.cols, opts), nil
+	case "chain":
+		return generators.Chain(cfg.n, opts), nil
+	case "star":
+		return generators.Star(cfg.n, opts), nil
+	case "dag":
+		return generators.CompleteDAG(cfg.n, opts), nil
+	}
+	return graphProbs.StringGraph{}, errors.New(fmt.Sprintf("unknown model: %s, expected one of %s", cfg.model, models))
+}
+
+// generateQueries uses -follower and -following when given, otherwise picks
+// random pairs of distinct nodes with a source derived from the seed.
+func generateQueries(cfg generateConfig, g *graphProbs.StringGraph) ([]graphProbs.Query, error) {
+	if cfg.follower != "" || cfg.following != "" {
+		for _, node := range []graphProbs.Node{cfg.follower, cfg.following} {
+			if !g.IsValidNode(node) {
+				return nil, errors.New(fmt.Sprintf("unknown node: %q", node))
+			}
+		}
+		return []graphProbs.Query{{Follower: cfg.follower, Following: cfg.following}}, nil
+	}
+	if cfg.queries < 1 {
+		return nil, errors.New("expected -queries of at least 1")
+	}
+	numNodes := len(g.Nodes())
+	rng := rand.New(rand.NewSource(cfg.seed + 1))
+	queries := make([]graphProbs.Query, cfg.queries)
+	for i := range queries {
+		follower, following := rng.Intn(numNodes), rng.Intn(numNodes)
+		// a node cannot be cut off from itself, so only a single node graph
+		// gets a query with both ends equal
+		for follower == following && numNodes > 1 {
+			following = rng.Intn(numNodes)
+		}
+		queries[i] = graphProbs.Query{Follower: strconv.Itoa(follower), Following: strconv.Itoa(following)}
+	}
+	return queries, nil
+}
+
+func runGenerate(args []string, stdout io.Writer, stderr io.Writer) int {
+	fs := flag.NewFlagSet("generate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	cfg := generateConfig{}
+	fs.StringVar(&cfg.model, "model", "er", "graph model, one of "+models)
+	fs.IntVar(&cfg.n, "n", 10, "number of nodes, for every model but grid")
+	fs.Float64Var(&cfg.p, "p", 0.2, "er: probability of every possible edge")
+	fs.IntVar(&cfg.m, "m", 2, "ba: number of earlier nodes every new node follows")
+	fs.IntVar(&cfg.rows, "rows", 3, "grid: number of rows")
+	fs.IntVar(&cfg.cols, "cols", 3, "grid: number of columns")
+	fs.Int64Var(&cfg.seed, "seed", 1, "seed of the random source, the same seed gives the same output")
+	fs.UintVar(&cfg.minWeight, "min-weight", 1, "smallest edge weight")
+	fs.UintVar(&cfg.maxWeight, "max-weight", 10, "largest edge weight")
+	fs.BoolVar(&cfg.weighted, "weighted", false, "write edge weights, as read by shortest and edgecut -weighted")
+	fs.IntVar(&cfg.queries, "queries", 1, "number of random queries, more than one are written in batch mode")
+	fs.StringVar(&cfg.follower, "follower", "", "follower of the single query, instead of a random one")
+	fs.StringVar(&cfg.following, "following", "", "following of the single query, instead of a random one")
+	fs.StringVar(&cfg.outputPath, "o", "", "write to this file instead of stdout")
+	err := fs.Parse(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOk
+		}
+		return exitUsage
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintln(stderr, "generate takes no inputFilePath")
+		return exitUsage
+	}
+
+	g, err := generateGraph(cfg)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+	queries, err := generateQueries(cfg, &g)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	w := stdout
+	var file *os.File
+	if cfg.outputPath != "" && cfg.outputPath != "-" {
+		file, err = os.Create(cfg.outputPath)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitFailure
+		}
+		w = file
+	}
+	opts := graphProbs.ProblemOptions{Weighted: cfg.weighted, Batch: len(queries) > 1}
+	err = graphProbs.WriteProblem(w, &graphProbs.Problem{Graph: g, Queries: queries}, opts)
+	if file != nil {
+		closeErr := file.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitFailure
+	}
+	return exitOk
+}
@@ -0,0 +1,186 @@
+package generators
+
+import (
+	"fmt"
+	"graphProbs/graphProbs"
+	"math"
+	"math/rand"
+	"strconv"
+)
+
+// Options are shared by all generators, which name nodes by their index, "0"
+// to "n-1", and draw from a source seeded with Seed, so the same Options
+// always give the same graph.
+type Options struct {
+	Seed int64
+	// Weights are drawn uniformly from [MinWeight, MaxWeight], all edges get
+	// MinWeight when MaxWeight is not larger.
+	MinWeight graphProbs.Weight
+	MaxWeight graphProbs.Weight
+}
+
+type builder struct {
+	opts  Options
+	rng   *rand.Rand
+	nodes []graphProbs.Node
+	edges []graphProbs.StringEdge
+}
+
+func newBuilder(n int, opts Options) *builder {
+	if n < 0 {
+		panic(fmt.Sprintf("generators: negative number of nodes %d", n))
+	}
+	nodes := make([]graphProbs.Node, n)
+	for i := range nodes {
+		nodes[i] = strconv.Itoa(i)
+	}
+	return &builder{opts: opts, rng: rand.New(rand.NewSource(opts.Seed)), nodes: nodes}
+}
+
+// uint64n draws uniformly from [0, n], unlike Int63n it takes the whole
+// range of uint64, rejecting draws from the incomplete last block of n+1.
+func (b *builder) uint64n(n uint64) uint64 {
+	if n == math.MaxUint64 {
+		return b.rng.Uint64()
+	}
+	limit := math.MaxUint64 - (math.MaxUint64-n)%(n+1)
+	for {
+		x := b.rng.Uint64()
+		if x <= limit {
+			return x % (n + 1)
+		}
+	}
+}
+
+func (b *builder) addEdge(u int, v int) {
+	wt := b.opts.MinWeight
+	if b.opts.MaxWeight > b.opts.MinWeight {
+		wt += graphProbs.Weight(b.uint64n(uint64(b.opts.MaxWeight - b.opts.MinWeight)))
+	}
+	b.edges = append(b.edges, graphProbs.StringEdge{Frm: b.nodes[u], To: b.nodes[v], Wt: wt})
+}
+
+func (b *builder) graph() graphProbs.StringGraph {
+	return graphProbs.MkGraph(b.edges, b.nodes)
+}
+
+// ErdosRenyi returns a G(n, p) graph, each of the n*(n-1) possible edges
+// u -> v with u != v is present with probability p. Edges are found by
+// skipping a geometrically distributed number of absent ones at a time, so
+// sparse graphs take time proportional to their number of edges.
+func ErdosRenyi(n int, p float64, opts Options) graphProbs.StringGraph {
+	if p < 0 || p > 1 {
+		panic(fmt.Sprintf("generators: edge probability %v is not in [0, 1]", p))
+	}
+	b := newBuilder(n, opts)
+	if n < 2 || p == 0 {
+		return b.graph()
+	}
+	total := int64(n) * int64(n-1)
+	logMiss := math.Log(1 - p)
+	for k := int64(-1); ; {
+		skip := 0.0
+		if p < 1 {
+			skip = math.Floor(math.Log(1-b.rng.Float64()) / logMiss)
+		}
+		if skip >= float64(total-k-1) {
+			break
+		}
+		k += 1 + int64(skip)
+		u, v := int(k/int64(n-1)), int(k%int64(n-1))
+		if v >= u {
+			v++
+		}
+		b.addEdge(u, v)
+	}
+	return b.graph()
+}
+
+// BarabasiAlbert grows a graph by preferential attachment: starting from m
+// nodes without edges, every new node follows m distinct earlier nodes, each
+// picked with probability proportional to its number of followers plus one.
+// The number of followers then follows a power law, as in social networks.
+func BarabasiAlbert(n int, m int, opts Options) graphProbs.StringGraph {
+	if m < 1 || m >= n {
+		panic(fmt.Sprintf("generators: m = %d must be between 1 and n - 1 = %d", m, n-1))
+	}
+	b := newBuilder(n, opts)
+	// every node appears in candidates once, plus once per follower, so that a
+	// uniform pick from it is the preferential one.
+	candidates := make([]int, 0, n*(m+1))
+	for u := 0; u < m; u++ {
+		candidates = append(candidates, u)
+	}
+	picked := make(map[int]bool, m)
+	targets := make([]int, 0, m)
+	for u := m; u < n; u++ {
+		for k := range picked {
+			delete(picked, k)
+		}
+		targets = targets[:0]
+		for len(targets) < m {
+			v := candidates[b.rng.Intn(len(candidates))]
+			if !picked[v] {
+				picked[v] = true
+				targets = append(targets, v)
+			}
+		}
+		for _, v := range targets {
+			b.addEdge(u, v)
+			candidates = append(candidates, v)
+		}
+		candidates = append(candidates, u)
+	}
+	return b.graph()
+}
+
+// Grid returns a rows by cols grid where node r*cols+c has edges to its right
+// and lower neighbors.
+func Grid(rows int, cols int, opts Options) graphProbs.StringGraph {
+	if rows < 0 || cols < 0 {
+		panic(fmt.Sprintf("generators: negative grid size %d x %d", rows, cols))
+	}
+	b := newBuilder(rows*cols, opts)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			u := r*cols + c
+			if c+1 < cols {
+				b.addEdge(u, u+1)
+			}
+			if r+1 < rows {
+				b.addEdge(u, u+cols)
+			}
+		}
+	}
+	return b.graph()
+}
+
+// Chain returns the path 0 -> 1 -> ... -> n-1.
+func Chain(n int, opts Options) graphProbs.StringGraph {
+	b := newBuilder(n, opts)
+	for u := 0; u+1 < n; u++ {
+		b.addEdge(u, u+1)
+	}
+	return b.graph()
+}
+
+// Star returns a hub 0 with an edge to every other node.
+func Star(n int, opts Options) graphProbs.StringGraph {
+	b := newBuilder(n, opts)
+	for v := 1; v < n; v++ {
+		b.addEdge(0, v)
+	}
+	return b.graph()
+}
+
+// CompleteDAG returns the acyclic tournament with an edge u -> v for every
+// u < v.
+func CompleteDAG(n int, opts Options) graphProbs.StringGraph {
+	b := newBuilder(n, opts)
+	for u := 0; u < n; u++ {
+		for v := u + 1; v < n; v++ {
+			b.addEdge(u, v)
+		}
+	}
+	return b.graph()
+}
@@ -0,0 +1,90 @@
+package generators
+
+import (
+	"bytes"
+	"graphProbs/graphProbs"
+	"math"
+	"testing"
+)
+
+func TestSizes(t *testing.T) {
+	opts := Options{Seed: 7, MinWeight: 1, MaxWeight: 5}
+	for _, tc := range []struct {
+		name     string
+		g        graphProbs.StringGraph
+		numNodes int
+		numEdges int
+	}{
+		{"complete er", ErdosRenyi(6, 1, opts), 6, 30},
+		{"empty er", ErdosRenyi(6, 0, opts), 6, 0},
+		{"ba", BarabasiAlbert(50, 3, opts), 50, 47 * 3},
+		{"grid", Grid(3, 4, opts), 12, 3*3 + 2*4},
+		{"chain", Chain(5, opts), 5, 4},
+		{"star", Star(5, opts), 5, 4},
+		{"dag", CompleteDAG(5, opts), 5, 10},
+	} {
+		if len(tc.g.Nodes()) != tc.numNodes || len(tc.g.Edges()) != tc.numEdges {
+			t.Errorf("%s: expected %d nodes and %d edges, got %d and %d", tc.name, tc.numNodes, tc.numEdges, len(tc.g.Nodes()), len(tc.g.Edges()))
+		}
+		for _, edge := range tc.g.Edges() {
+			if edge.Wt < 1 || edge.Wt > 5 || edge.Frm == edge.To {
+				t.Errorf("%s: unexpected edge %v", tc.name, edge)
+			}
+		}
+	}
+}
+
+func TestErdosRenyiDensity(t *testing.T) {
+	g := ErdosRenyi(200, 0.05, Options{Seed: 1})
+	expected := 0.05 * 200 * 199
+	got := float64(len(g.Edges()))
+	if got < 0.9*expected || got > 1.1*expected {
+		t.Errorf("expected about %v edges, got %v", expected, got)
+	}
+}
+
+func TestSameSeedSameGraph(t *testing.T) {
+	write := func(g graphProbs.StringGraph) string {
+		var buf bytes.Buffer
+		problem := &graphProbs.Problem{Graph: g, Queries: []graphProbs.Query{{Follower: "0", Following: "1"}}}
+		err := graphProbs.WriteProblem(&buf, problem, graphProbs.ProblemOptions{Weighted: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+	opts := Options{Seed: 3, MaxWeight: 100}
+	if write(BarabasiAlbert(100, 2, opts)) != write(BarabasiAlbert(100, 2, opts)) {
+		t.Error("expected the same Barabasi-Albert graph for the same seed")
+	}
+	if write(ErdosRenyi(100, 0.1, opts)) != write(ErdosRenyi(100, 0.1, opts)) {
+		t.Error("expected the same Erdos-Renyi graph for the same seed")
+	}
+	if write(ErdosRenyi(100, 0.1, opts)) == write(ErdosRenyi(100, 0.1, Options{Seed: 4, MaxWeight: 100})) {
+		t.Error("expected different Erdos-Renyi graphs for different seeds")
+	}
+
+	text := write(Grid(2, 2, Options{MinWeight: 2}))
+	problem, err := graphProbs.ParseProblem(bytes.NewBufferString(text), graphProbs.ProblemOptions{Weighted: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if write(problem.Graph) != text {
+		t.Errorf("expected WriteProblem and ParseProblem to round trip, got %q", text)
+	}
+}
+
+func TestFullWeightRange(t *testing.T) {
+	for _, opts := range []Options{
+		{Seed: 1, MaxWeight: math.MaxInt64},
+		{Seed: 2, MaxWeight: math.MaxUint64},
+		{Seed: 3, MinWeight: math.MaxUint64 - 2, MaxWeight: math.MaxUint64},
+	} {
+		g := CompleteDAG(10, opts)
+		for _, edge := range g.Edges() {
+			if edge.Wt < opts.MinWeight || edge.Wt > opts.MaxWeight {
+				t.Errorf("%+v: weight %d out of range", opts, edge.Wt)
+			}
+		}
+	}
+}
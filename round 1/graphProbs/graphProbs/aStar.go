@@ -0,0 +1,106 @@
+package graphProbs
+
+import "container/heap"
+
+type Heuristic[N Ordered, W Number] func(N) W
+
+// AStar finds a shortest path from start to end, expanding nodes in order of
+// their distance from start plus heuristic(node). The result is only optimal
+// when the heuristic is consistent, i.e. never overestimates an edge.
+func (g *Graph[N, W]) AStar(start N, end N, heuristic Heuristic[N, W]) (*Path[N, W], error) {
+	if !g.IsValidNode(start) || !g.IsValidNode(end) {
+		return nil, nil
+	}
+	err := g.CheckWeightsNonNegative()
+	if err != nil {
+		return nil, err
+	}
+	dists := map[N]W{start: 0}
+	preds := map[N]Edge[N, W]{}
+	pq := pairHeap[N, W]{pair[N, W]{node: start, weight: heuristic(start)}}
+	visited := map[N]bool{}
+	for {
+		if len(pq) == 0 {
+			break
+		}
+		pr := heap.Pop(&pq).(pair[N, W])
+		_, ok := visited[pr.node]
+		if ok {
+			continue
+		}
+		visited[pr.node] = true
+		if pr.node == end {
+			return tracePath(preds, start, end, dists[end]), nil
+		}
+		du := dists[pr.node]
+		for _, neighbor := range g.Neighbors(pr.node) {
+			vnode := neighbor.To
+			_, ok := visited[vnode]
+			if ok {
+				continue
+			}
+			dv, ok := dists[vnode]
+			if !ok || du+neighbor.Wt < dv {
+				dists[vnode] = du + neighbor.Wt
+				preds[vnode] = neighbor
+				heap.Push(&pq, pair[N, W]{node: vnode, weight: du + neighbor.Wt + heuristic(vnode)})
+			}
+		}
+	}
+	return nil, nil
+}
+
+// Landmarks holds exact distances to and from a few chosen nodes, used to
+// bound the remaining distance with the triangle inequality (ALT).
+type Landmarks[N Ordered, W Number] struct {
+	from []map[N]W
+	to   []map[N]W
+}
+
+func (g *Graph[N, W]) PrecomputeLandmarks(landmarks []N) (*Landmarks[N, W], error) {
+	err := g.CheckWeightsNonNegative()
+	if err != nil {
+		return nil, err
+	}
+	reversed := g.Transpose()
+	l := &Landmarks[N, W]{}
+	for _, landmark := range landmarks {
+		if !g.IsValidNode(landmark) {
+			continue
+		}
+		from, _ := g.dijkstra(landmark, nil, nil, nil)
+		to, _ := reversed.dijkstra(landmark, nil, nil, nil)
+		l.from = append(l.from, from)
+		l.to = append(l.to, to)
+	}
+	return l, nil
+}
+
+func gap[W Number](a W, b W) W {
+	if a > b {
+		return a - b
+	}
+	return 0
+}
+
+// Heuristic returns a consistent lower bound on the distance to end. For a
+// landmark L, d(v, end) >= d(L, end) - d(L, v) and d(v, end) >= d(v, L) - d(end, L).
+// Bounds that involve an unreachable landmark are skipped.
+func (l *Landmarks[N, W]) Heuristic(end N) Heuristic[N, W] {
+	return func(v N) W {
+		best := W(0)
+		for i := range l.from {
+			fromEnd, okEnd := l.from[i][end]
+			fromV, okV := l.from[i][v]
+			if okEnd && okV && gap(fromEnd, fromV) > best {
+				best = gap(fromEnd, fromV)
+			}
+			toV, okV := l.to[i][v]
+			toEnd, okEnd := l.to[i][end]
+			if okEnd && okV && gap(toV, toEnd) > best {
+				best = gap(toV, toEnd)
+			}
+		}
+		return best
+	}
+}
@@ -0,0 +1,85 @@
+package graphProbs
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func randomGraph(rng *rand.Rand, numNodes int, numEdges int, maxWt int) StringGraph {
+	nodes := make([]Node, numNodes)
+	for i := range nodes {
+		nodes[i] = strconv.Itoa(i)
+	}
+	edges := make([]StringEdge, numEdges)
+	for i := range edges {
+		edges[i] = StringEdge{
+			Frm: nodes[rng.Intn(numNodes)],
+			To:  nodes[rng.Intn(numNodes)],
+			Wt:  Weight(rng.Intn(maxWt + 1)),
+		}
+	}
+	return MkGraph(edges, nodes)
+}
+
+func assertSameWeight(t *testing.T, g *StringGraph, start Node, end Node, path *StringPath, err error) {
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := g.ShortestTime(start, end)
+	if expected == nil || path == nil {
+		if expected != nil || path != nil {
+			t.Errorf("%s -> %s: reachability differs, ShortestTime: %v, AStar: %v", start, end, expected, path)
+		}
+		return
+	}
+	if *expected != path.Wt {
+		t.Errorf("%s -> %s: ShortestTime: %d, AStar: %d", start, end, *expected, path.Wt)
+	}
+	total := Weight(0)
+	for _, edge := range path.Edges {
+		total += edge.Wt
+	}
+	if total != path.Wt {
+		t.Errorf("%s -> %s: path edges sum to %d, reported %d", start, end, total, path.Wt)
+	}
+}
+
+func TestAStarMatchesShortestTime(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	for round := 0; round < 20; round++ {
+		g := randomGraph(rng, 30, 90, 10)
+		nodes := g.sortedNodes()
+		landmarks, err := g.PrecomputeLandmarks([]Node{nodes[0], nodes[len(nodes)/2], nodes[len(nodes)-1]})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, start := range nodes {
+			for _, end := range nodes {
+				path, err := g.AStar(start, end, func(Node) Weight { return 0 })
+				assertSameWeight(t, &g, start, end, path, err)
+				path, err = g.AStar(start, end, landmarks.Heuristic(end))
+				assertSameWeight(t, &g, start, end, path, err)
+			}
+		}
+	}
+}
+
+func TestLandmarkHeuristicIsAdmissible(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	g := randomGraph(rng, 40, 120, 20)
+	nodes := g.sortedNodes()
+	landmarks, err := g.PrecomputeLandmarks(nodes[:4])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, end := range nodes {
+		heuristic := landmarks.Heuristic(end)
+		for _, v := range nodes {
+			actual := g.ShortestTime(v, end)
+			if actual != nil && heuristic(v) > *actual {
+				t.Errorf("h(%s) = %d overestimates d(%s, %s) = %d", v, heuristic(v), v, end, *actual)
+			}
+		}
+	}
+}
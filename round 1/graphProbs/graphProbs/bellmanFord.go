@@ -0,0 +1,97 @@
+package graphProbs
+
+import (
+	"fmt"
+	"strings"
+)
+
+type NegativeCycleError[N Ordered, W Number] struct {
+	Cycle []Edge[N, W]
+}
+
+func (e *NegativeCycleError[N, W]) Error() string {
+	nodes := make([]N, 0, len(e.Cycle)+1)
+	total := W(0)
+	for _, edge := range e.Cycle {
+		nodes = append(nodes, edge.Frm)
+		total += edge.Wt
+	}
+	if len(e.Cycle) > 0 {
+		nodes = append(nodes, e.Cycle[0].Frm)
+	}
+	return fmt.Sprintf("negative cycle of weight %v: %s", total, strings.Join(formatNodes(nodes), " -> "))
+}
+
+// BellmanFord computes shortest paths from start using the queue based SPFA
+// variant, which unlike dijkstra allows negative weights. Once some shortest
+// path has grown to |V| edges a negative cycle is reachable from start, and
+// relaxation goes on until that cycle shows up in the predecessor graph, from
+// where it is returned as a *NegativeCycleError. Every negative edge of an
+// undirected graph is such a cycle.
+func (g *Graph[N, W]) BellmanFord(start N) (*ShortestPathTree[N, W], error) {
+	if !g.IsValidNode(start) {
+		return nil, &NodeNotFoundError[N]{Node: start}
+	}
+	dists := map[N]W{start: 0}
+	preds := map[N]Edge[N, W]{}
+	hops := map[N]int{start: 0}
+	queue := []N{start}
+	queued := map[N]bool{start: true}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		queued[u] = false
+		for _, neighbor := range g.Neighbors(u) {
+			v := neighbor.To
+			dv, ok := dists[v]
+			if ok && dists[u]+neighbor.Wt >= dv {
+				continue
+			}
+			dists[v] = dists[u] + neighbor.Wt
+			preds[v] = neighbor
+			hops[v] = hops[u] + 1
+			if hops[v] >= len(g.nodes) {
+				cycle := predecessorCycle(preds, v)
+				if cycle != nil {
+					return nil, &NegativeCycleError[N, W]{Cycle: cycle}
+				}
+			}
+			if !queued[v] {
+				queued[v] = true
+				queue = append(queue, v)
+			}
+		}
+	}
+	return &ShortestPathTree[N, W]{Root: start, Dists: dists, Preds: preds}, nil
+}
+
+// predecessorCycle follows predecessor edges back from v, returning the cycle
+// they run into in forward order, or nil if the walk ends at a node without a
+// predecessor.
+func predecessorCycle[N Ordered, W Number](preds map[N]Edge[N, W], v N) []Edge[N, W] {
+	seen := map[N]bool{}
+	for {
+		if seen[v] {
+			break
+		}
+		seen[v] = true
+		edge, ok := preds[v]
+		if !ok {
+			return nil
+		}
+		v = edge.Frm
+	}
+	cycle := []Edge[N, W]{}
+	for node := v; ; {
+		edge := preds[node]
+		cycle = append(cycle, edge)
+		node = edge.Frm
+		if node == v {
+			break
+		}
+	}
+	for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+		cycle[i], cycle[j] = cycle[j], cycle[i]
+	}
+	return cycle
+}
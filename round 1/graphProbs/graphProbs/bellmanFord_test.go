@@ -0,0 +1,66 @@
+package graphProbs
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestBellmanFordReportsNegativeCycle(t *testing.T) {
+	g := MkGraph([]SignedEdge{
+		{Frm: "s", To: "a", Wt: 1},
+		{Frm: "a", To: "b", Wt: 2},
+		{Frm: "b", To: "c", Wt: -4},
+		{Frm: "c", To: "a", Wt: 1},
+		{Frm: "c", To: "t", Wt: 3},
+	}, nil)
+	_, err := g.BellmanFord("s")
+	var cycleErr *NegativeCycleError[Node, SignedWeight]
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("expected a NegativeCycleError, got %v", err)
+	}
+	cycle := cycleErr.Cycle
+	// the cycle may start anywhere, rotate it to start at its smallest node
+	first := 0
+	for i, edge := range cycle {
+		if edge.Frm < cycle[first].Frm {
+			first = i
+		}
+	}
+	cycle = append(cycle[first:], cycle[:first]...)
+	expected := []SignedEdge{{Frm: "a", To: "b", Wt: 2}, {Frm: "b", To: "c", Wt: -4}, {Frm: "c", To: "a", Wt: 1}}
+	if len(cycle) != len(expected) {
+		t.Fatalf("expected the cycle %v, got %v", expected, cycle)
+	}
+	total := SignedWeight(0)
+	for i, edge := range cycle {
+		if edge != expected[i] {
+			t.Errorf("expected the cycle %v, got %v", expected, cycle)
+		}
+		total += edge.Wt
+	}
+	if total != -1 {
+		t.Errorf("expected the cycle to weigh -1, got %d", total)
+	}
+	if !strings.HasPrefix(err.Error(), "negative cycle of weight -1: ") {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+
+	tree, err := g.BellmanFord("c")
+	if err == nil {
+		t.Errorf("expected the cycle to be reachable from c too, got %v", tree.Dists)
+	}
+	tree, err = g.BellmanFord("t")
+	if err != nil || len(tree.Dists) != 1 {
+		t.Errorf("expected only t to be reachable from t, got %v %v", tree, err)
+	}
+}
+
+func TestBellmanFordUndirectedNegativeEdgeIsACycle(t *testing.T) {
+	g := MkUndirectedGraph([]SignedEdge{{Frm: "a", To: "b", Wt: -1}}, nil)
+	_, err := g.BellmanFord("a")
+	var cycleErr *NegativeCycleError[Node, SignedWeight]
+	if !errors.As(err, &cycleErr) || len(cycleErr.Cycle) != 2 {
+		t.Errorf("expected a -- b to be a negative cycle, got %v", err)
+	}
+}
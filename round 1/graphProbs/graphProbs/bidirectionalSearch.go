@@ -0,0 +1,106 @@
+package graphProbs
+
+// MeetingPath is a fewest hops path found by a bidirectional search, Meeting
+// is the node where the forward and backward searches met.
+type MeetingPath[N Ordered] struct {
+	Nodes   []N
+	Meeting N
+}
+
+func (p *MeetingPath[N]) Hops() int {
+	return len(p.Nodes) - 1
+}
+
+type searchSide[N Ordered] struct {
+	frontier []N
+	parents  map[N]N
+	depths   map[N]int
+}
+
+func newSearchSide[N Ordered](root N) *searchSide[N] {
+	return &searchSide[N]{
+		frontier: []N{root},
+		parents:  map[N]N{},
+		depths:   map[N]int{root: 0},
+	}
+}
+
+func (s *searchSide[N]) chain(n N) []N {
+	chain := []N{n}
+	for {
+		parent, ok := s.parents[n]
+		if !ok {
+			return chain
+		}
+		chain = append(chain, parent)
+		n = parent
+	}
+}
+
+// BidirectionalSearch runs breadth first searches forward from frm and
+// backward from to, always expanding a whole layer of the smaller frontier.
+// The first layer in which the searches touch yields a fewest hops path.
+func (g *Graph[N, W]) BidirectionalSearch(frm N, to N) *MeetingPath[N] {
+	if frm == to {
+		return &MeetingPath[N]{Nodes: []N{frm}, Meeting: frm}
+	}
+	if !g.IsValidNode(frm) || !g.IsValidNode(to) {
+		return nil
+	}
+	forward := newSearchSide(frm)
+	backward := newSearchSide(to)
+	for len(forward.frontier) > 0 && len(backward.frontier) > 0 {
+		isForward := len(forward.frontier) <= len(backward.frontier)
+		side, other, next := forward, backward, g.Neighbors
+		if !isForward {
+			side, other, next = backward, forward, g.InNeighbors
+		}
+
+		var meeting N
+		bestHops := -1
+		nextFrontier := []N{}
+		for _, u := range side.frontier {
+			for _, edge := range next(u) {
+				v := edge.To
+				if !isForward {
+					v = edge.Frm
+				}
+				_, ok := side.depths[v]
+				if ok {
+					continue
+				}
+				side.depths[v] = side.depths[u] + 1
+				side.parents[v] = u
+				nextFrontier = append(nextFrontier, v)
+				otherDepth, ok := other.depths[v]
+				if ok && (bestHops < 0 || side.depths[v]+otherDepth < bestHops || (side.depths[v]+otherDepth == bestHops && v < meeting)) {
+					meeting, bestHops = v, side.depths[v]+otherDepth
+				}
+			}
+		}
+		side.frontier = nextFrontier
+		if bestHops >= 0 {
+			nodes := forward.chain(meeting)
+			for i, j := 0, len(nodes)-1; i < j; i, j = i+1, j-1 {
+				nodes[i], nodes[j] = nodes[j], nodes[i]
+			}
+			nodes = append(nodes, backward.chain(meeting)[1:]...)
+			return &MeetingPath[N]{Nodes: nodes, Meeting: meeting}
+		}
+	}
+	return nil
+}
+
+func (g *Graph[N, W]) CanReachBidirectional(frm N, to N) bool {
+	return g.BidirectionalSearch(frm, to) != nil
+}
+
+// HopDistance is the number of edges on a fewest hops path from frm to to, the
+// second result is false when to is unreachable.
+func (g *Graph[N, W]) HopDistance(frm N, to N) (int, bool) {
+	path := g.BidirectionalSearch(frm, to)
+	if path == nil {
+		return 0, false
+	}
+	return path.Hops(), true
+}
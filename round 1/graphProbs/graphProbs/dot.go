@@ -0,0 +1,302 @@
+package graphProbs
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type DOTOptions[N Ordered, W Number] struct {
+	Name string
+	// HighlightPath, e.g. the one behind ShortestTime, has its edges and nodes
+	// drawn in red.
+	HighlightPath *Path[N, W]
+	// HighlightNodes, e.g. the nodes to block, are filled in light blue.
+	HighlightNodes []N
+}
+
+func dotId(s string) string {
+	return `"` + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), `"`, `\"`) + `"`
+}
+
+// WriteDOT writes g as a Graphviz digraph, or graph when g is undirected, with
+// weights only as edge labels, as Graphviz reads a weight attribute as a layout
+// hint that has to be an integer. Nodes and edges are written in sorted order so the
+// output is reproducible, nodes and weights are named as printed by fmt. The
+// labels of multigraph edges are not written, use WriteJSON or WriteGraphML to
+// keep them.
+func (g *Graph[N, W]) WriteDOT(w io.Writer, opts DOTOptions[N, W]) error {
+	name := opts.Name
+	if name == "" {
+		name = "G"
+	}
+	pathNodes := map[N]bool{}
+	pathEdges := map[Edge[N, W]]bool{}
+	if opts.HighlightPath != nil {
+		for _, node := range opts.HighlightPath.Nodes() {
+			pathNodes[node] = true
+		}
+		for _, edge := range opts.HighlightPath.Edges {
+			pathEdges[edge] = true
+		}
+	}
+	highlighted := map[N]bool{}
+	for _, node := range opts.HighlightNodes {
+		highlighted[node] = true
+	}
+
+	kind, edgeOp := "digraph", "->"
+	if g.undirected {
+		kind, edgeOp = "graph", "--"
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s %s {\n", kind, dotId(name))
+	for _, node := range g.sortedNodes() {
+		attrs := []string{}
+		if pathNodes[node] {
+			attrs = append(attrs, "color=red", "penwidth=2")
+		}
+		if highlighted[node] {
+			attrs = append(attrs, "style=filled", "fillcolor=lightblue")
+		}
+		fmt.Fprintf(bw, "\t%s%s;\n", dotId(fmt.Sprint(node)), dotAttrs(attrs))
+	}
+	edges := g.Edges()
+	sortEdges(edges)
+	for _, edge := range edges {
+		attrs := []string{fmt.Sprintf("label=%s", dotId(fmt.Sprint(edge.Wt)))}
+		if g.multi {
+			attrs = append(attrs, fmt.Sprintf("id=%d", edge.Id))
+		}
+		onPath := pathEdges[edge]
+		if g.undirected {
+			onPath = onPath || pathEdges[reversedEdge(edge)]
+		}
+		if onPath {
+			attrs = append(attrs, "color=red", "penwidth=2")
+		}
+		fmt.Fprintf(bw, "\t%s %s %s%s;\n", dotId(fmt.Sprint(edge.Frm)), edgeOp, dotId(fmt.Sprint(edge.To)), dotAttrs(attrs))
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+func dotAttrs(attrs []string) string {
+	if len(attrs) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(attrs, ", ") + "]"
+}
+
+type DOTSyntaxError struct {
+	Line int
+	Msg  string
+}
+
+func (e *DOTSyntaxError) Error() string {
+	return fmt.Sprintf("dot: line %d: %s", e.Line, e.Msg)
+}
+
+const (
+	dotTokenId = iota
+	dotTokenPunct
+)
+
+type dotToken struct {
+	kind  int
+	value string
+}
+
+func tokenizeDOTLine(line string) ([]dotToken, error) {
+	tokens := []dotToken{}
+	for i := 0; i < len(line); {
+		c := line[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c == '/' && strings.HasPrefix(line[i:], "//"), c == '#':
+			return tokens, nil
+		case c == '-' && (strings.HasPrefix(line[i:], "->") || strings.HasPrefix(line[i:], "--")):
+			tokens = append(tokens, dotToken{kind: dotTokenPunct, value: line[i : i+2]})
+			i += 2
+		case strings.IndexByte("{}[]=,;", c) >= 0:
+			tokens = append(tokens, dotToken{kind: dotTokenPunct, value: string(c)})
+			i++
+		case c == '"':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(line) && line[j] != '"'; j++ {
+				if line[j] == '\\' && j+1 < len(line) {
+					j++
+				}
+				sb.WriteByte(line[j])
+			}
+			if j >= len(line) {
+				return nil, errors.New("unterminated quoted string")
+			}
+			tokens = append(tokens, dotToken{kind: dotTokenId, value: sb.String()})
+			i = j + 1
+		default:
+			j := i
+			for j < len(line) && (line[j] == '_' || line[j] == '.' || line[j] == '-' && !strings.HasPrefix(line[j:], "->") && !strings.HasPrefix(line[j:], "--") ||
+				'0' <= line[j] && line[j] <= '9' || 'a' <= line[j] && line[j] <= 'z' || 'A' <= line[j] && line[j] <= 'Z') {
+				j++
+			}
+			if j == i {
+				return nil, fmt.Errorf("unexpected character %q", c)
+			}
+			tokens = append(tokens, dotToken{kind: dotTokenId, value: line[i:j]})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+// parseDOTAttrs reads a bracketed attribute list starting at tokens[0] and
+// returns the attributes and the number of tokens consumed.
+func parseDOTAttrs(tokens []dotToken) (map[string]string, int, error) {
+	attrs := map[string]string{}
+	if len(tokens) == 0 || tokens[0].value != "[" {
+		return attrs, 0, nil
+	}
+	i := 1
+	for {
+		if i >= len(tokens) {
+			return nil, 0, errors.New("unterminated attribute list")
+		}
+		if tokens[i].kind == dotTokenPunct && tokens[i].value == "]" {
+			return attrs, i + 1, nil
+		}
+		if tokens[i].kind == dotTokenPunct && (tokens[i].value == "," || tokens[i].value == ";") {
+			i++
+			continue
+		}
+		if i+2 >= len(tokens) || tokens[i].kind != dotTokenId || tokens[i+1].value != "=" || tokens[i+2].kind != dotTokenId {
+			return nil, 0, errors.New("expected key=value in attribute list")
+		}
+		attrs[tokens[i].value] = tokens[i+2].value
+		i += 3
+	}
+}
+
+// ReadDOT parses the subset of DOT written by WriteDOT: a single digraph made
+// of node statements and edge statements such as `a -> b [label="3"]` or
+// `a -> b [weight=3]`, or a graph using `a -- b`, which is read as an
+// undirected Graph. The weight attribute takes precedence over the label,
+// which is only read as a weight when it is a number, and edges with neither
+// get weight 0. As soon as one edge has an id attribute the graph is read as a
+// multigraph keeping those ids, other attributes are ignored.
+func ReadDOT(r io.Reader) (StringGraph, error) {
+	nodes := []Node{}
+	edges := []StringEdge{}
+	ids := map[EdgeId]bool{}
+	edgeOp := "->"
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	opened, closed := false, false
+	for scanner.Scan() {
+		lineNo++
+		tokens, err := tokenizeDOTLine(scanner.Text())
+		if err != nil {
+			return StringGraph{}, &DOTSyntaxError{Line: lineNo, Msg: err.Error()}
+		}
+		for len(tokens) > 0 {
+			if closed {
+				return StringGraph{}, &DOTSyntaxError{Line: lineNo, Msg: "content after closing brace"}
+			}
+			switch {
+			case tokens[0].value == ";":
+				tokens = tokens[1:]
+				continue
+			case !opened:
+				if tokens[0].kind != dotTokenId || (tokens[0].value != "digraph" && tokens[0].value != "graph") {
+					return StringGraph{}, &DOTSyntaxError{Line: lineNo, Msg: "expected digraph or graph"}
+				}
+				if tokens[0].value == "graph" {
+					edgeOp = "--"
+				}
+				tokens = tokens[1:]
+				if len(tokens) > 0 && tokens[0].kind == dotTokenId {
+					tokens = tokens[1:]
+				}
+				if len(tokens) == 0 || tokens[0].value != "{" {
+					return StringGraph{}, &DOTSyntaxError{Line: lineNo, Msg: "expected {"}
+				}
+				tokens = tokens[1:]
+				opened = true
+				continue
+			case tokens[0].kind == dotTokenPunct && tokens[0].value == "}":
+				tokens = tokens[1:]
+				closed = true
+				continue
+			case tokens[0].kind != dotTokenId:
+				return StringGraph{}, &DOTSyntaxError{Line: lineNo, Msg: fmt.Sprintf("unexpected %q", tokens[0].value)}
+			}
+
+			stmt := []Node{tokens[0].value}
+			tokens = tokens[1:]
+			for len(tokens) >= 2 && tokens[0].value == edgeOp && tokens[1].kind == dotTokenId {
+				stmt = append(stmt, tokens[1].value)
+				tokens = tokens[2:]
+			}
+			if len(tokens) > 0 && (tokens[0].value == "->" || tokens[0].value == "--") {
+				if tokens[0].value != edgeOp {
+					return StringGraph{}, &DOTSyntaxError{Line: lineNo, Msg: fmt.Sprintf("%s is not allowed here, edges use %s", tokens[0].value, edgeOp)}
+				}
+				return StringGraph{}, &DOTSyntaxError{Line: lineNo, Msg: "expected node after " + edgeOp}
+			}
+			attrs, consumed, err := parseDOTAttrs(tokens)
+			if err != nil {
+				return StringGraph{}, &DOTSyntaxError{Line: lineNo, Msg: err.Error()}
+			}
+			tokens = tokens[consumed:]
+
+			if len(stmt) == 1 {
+				switch stmt[0] {
+				case "graph", "node", "edge":
+				default:
+					nodes = append(nodes, stmt[0])
+				}
+				continue
+			}
+			wt := Weight(0)
+			wtStr, ok := attrs["weight"]
+			if ok {
+				parsed, err := strconv.ParseUint(wtStr, 10, 0)
+				if err != nil {
+					return StringGraph{}, &DOTSyntaxError{Line: lineNo, Msg: fmt.Sprintf("invalid weight %q", wtStr)}
+				}
+				wt = Weight(parsed)
+			} else if parsed, err := strconv.ParseUint(attrs["label"], 10, 0); err == nil {
+				wt = Weight(parsed)
+			}
+			id := EdgeId(0)
+			idStr, ok := attrs["id"]
+			if ok {
+				id, err = strconv.ParseUint(idStr, 10, 64)
+				if err != nil || id == 0 {
+					return StringGraph{}, &DOTSyntaxError{Line: lineNo, Msg: fmt.Sprintf("invalid id %q", idStr)}
+				}
+				if ids[id] || len(stmt) > 2 {
+					return StringGraph{}, &DOTSyntaxError{Line: lineNo, Msg: fmt.Sprintf("id %d is given to more than one edge", id)}
+				}
+				ids[id] = true
+			}
+			for i := 0; i+1 < len(stmt); i++ {
+				edges = append(edges, StringEdge{Frm: stmt[i], To: stmt[i+1], Wt: wt, Id: id})
+			}
+		}
+	}
+	err := scanner.Err()
+	if err != nil {
+		return StringGraph{}, err
+	}
+	if !closed {
+		return StringGraph{}, &DOTSyntaxError{Line: lineNo, Msg: "missing closing brace"}
+	}
+	opts := GraphOptions{Undirected: edgeOp == "--", Multi: len(ids) > 0}
+	return MkGraphWithOptions(opts, edges, nodes), nil
+}
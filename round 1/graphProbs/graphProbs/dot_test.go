@@ -0,0 +1,100 @@
+package graphProbs
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func assertSameGraph(t *testing.T, name string, expected *StringGraph, got *StringGraph) {
+	t.Helper()
+	if expected.IsUndirected() != got.IsUndirected() || expected.IsMulti() != got.IsMulti() {
+		t.Errorf("%s: expected undirected=%v multi=%v, got %v %v", name, expected.IsUndirected(), expected.IsMulti(), got.IsUndirected(), got.IsMulti())
+	}
+	expectedNodes, nodes := expected.sortedNodes(), got.sortedNodes()
+	if strings.Join(expectedNodes, "|") != strings.Join(nodes, "|") {
+		t.Errorf("%s: expected nodes %q, got %q", name, expectedNodes, nodes)
+	}
+	expectedEdges, edges := expected.Edges(), got.Edges()
+	sortEdges(expectedEdges)
+	sortEdges(edges)
+	if len(edges) != len(expectedEdges) {
+		t.Fatalf("%s: expected edges %v, got %v", name, expectedEdges, edges)
+	}
+	for i := range edges {
+		if edges[i] != expectedEdges[i] {
+			t.Errorf("%s: expected edge %v, got %v", name, expectedEdges[i], edges[i])
+		}
+	}
+}
+
+func roundTripGraphs() map[string]StringGraph {
+	edges := []StringEdge{
+		{Frm: "a", To: "b", Wt: 3},
+		{Frm: "b", To: "a", Wt: 0},
+		{Frm: "b", To: `say "hi"`, Wt: 18446744073709551615},
+		{Frm: "node with spaces", To: "a", Wt: 7},
+	}
+	return map[string]StringGraph{
+		"directed":   MkGraph(edges, []Node{"isolated", `back\slash`}),
+		"undirected": MkUndirectedGraph(edges[2:], []Node{"isolated"}),
+		"empty":      MkGraph[Node, Weight](nil, nil),
+	}
+}
+
+func TestDOTRoundTrip(t *testing.T) {
+	for name, g := range roundTripGraphs() {
+		path := g.ShortestPath("a", `say "hi"`)
+		var buf bytes.Buffer
+		err := g.WriteDOT(&buf, DOTOptions[Node, Weight]{Name: "round trip", HighlightPath: path, HighlightNodes: []Node{"b"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(buf.String(), "weight=") {
+			t.Errorf("%s: expected weights only as labels, got\n%s", name, buf.String())
+		}
+		read, err := ReadDOT(&buf)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		assertSameGraph(t, name, &g, &read)
+	}
+}
+
+func TestReadDOT(t *testing.T) {
+	g, err := ReadDOT(strings.NewReader(`digraph {
+		// weight takes precedence over the label, which need not be a number
+		a -> b [weight=2, label="5"]; b -> c [label="4"]
+		c -> d -> e [label=informal]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := MkGraph([]StringEdge{
+		{Frm: "a", To: "b", Wt: 2},
+		{Frm: "b", To: "c", Wt: 4},
+		{Frm: "c", To: "d", Wt: 0},
+		{Frm: "d", To: "e", Wt: 0},
+	}, nil)
+	assertSameGraph(t, "weights", &expected, &g)
+
+	for _, tc := range []struct {
+		input string
+		line  int
+	}{
+		{"digraph {\n a -> b [weight=-1]\n}", 2},
+		{"digraph {\n a -- b\n}", 2},
+		{"graph {\n a -> b\n}", 2},
+		{"digraph {\n a -> b [label=\"1\"\n}", 2},
+		{"digraph {\n a -> b\n", 2},
+		{"digraph {\n a -> b\n}\n c", 4},
+		{"strict digraph {}", 1},
+	} {
+		_, err := ReadDOT(strings.NewReader(tc.input))
+		var syntaxErr *DOTSyntaxError
+		if !errors.As(err, &syntaxErr) || syntaxErr.Line != tc.line {
+			t.Errorf("%q: expected a DOTSyntaxError at line %d, got %v", tc.input, tc.line, err)
+		}
+	}
+}
@@ -1,45 +1,72 @@
 package graphProbs
 
-func (g *Graph) ReachableNodes(frm Node, blockedNodes map[Node]bool) <-chan Node {
-	retCh := make(chan Node)
+import "context"
+
+// WalkReachableNodes calls visit on every node reachable from frm without
+// passing through blockedNodes, in breadth first order, on the calling
+// goroutine. The walk stops early as soon as visit returns false.
+func (g *Graph[N, W]) WalkReachableNodes(frm N, blockedNodes map[N]bool, visit func(N) bool) {
 	if blockedNodes == nil {
-		blockedNodes = map[Node]bool{}
+		blockedNodes = map[N]bool{}
 	}
-	go func() {
-		frontier := []Node{frm}
-		visited := map[Node]bool{frm: true}
-		for {
-			if len(frontier) == 0 {
-				break
+	frontier := []N{frm}
+	visited := map[N]bool{frm: true}
+	for {
+		if len(frontier) == 0 {
+			break
+		}
+		nextFrontier := []N{}
+		for _, node := range frontier {
+			if !visit(node) {
+				return
 			}
-			nextFrontier := []Node{}
-			for _, node := range frontier {
-				retCh <- node
-				for _, neighbor := range g.Neighbors(node) {
-					_, ok := blockedNodes[neighbor.To]
-					if ok {
-						continue
-					}
-					_, ok = visited[neighbor.To]
-					if ok {
-						continue
-					}
-					nextFrontier = append(nextFrontier, neighbor.To)
-					visited[neighbor.To] = true
+			for _, neighbor := range g.Neighbors(node) {
+				_, ok := blockedNodes[neighbor.To]
+				if ok {
+					continue
+				}
+				_, ok = visited[neighbor.To]
+				if ok {
+					continue
 				}
+				nextFrontier = append(nextFrontier, neighbor.To)
+				visited[neighbor.To] = true
 			}
-			frontier = nextFrontier
 		}
-		close(retCh)
+		frontier = nextFrontier
+	}
+}
+
+// ReachableNodesContext streams the nodes of WalkReachableNodes over a channel.
+// The producing goroutine exits, closing the channel, once the walk is done or
+// ctx is cancelled, so consumers that stop reading early must cancel ctx.
+func (g *Graph[N, W]) ReachableNodesContext(ctx context.Context, frm N, blockedNodes map[N]bool) <-chan N {
+	retCh := make(chan N)
+	go func() {
+		defer close(retCh)
+		g.WalkReachableNodes(frm, blockedNodes, func(node N) bool {
+			select {
+			case retCh <- node:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
 	}()
 	return retCh
 }
 
-func (g *Graph) CanReach(frm Node, to Node) bool {
-	for node := range g.ReachableNodes(frm, nil) {
-		if node == to {
-			return true
-		}
-	}
-	return false
+// ReachableNodes must be drained completely, otherwise its goroutine is left
+// blocked, use ReachableNodesContext or WalkReachableNodes to stop early.
+func (g *Graph[N, W]) ReachableNodes(frm N, blockedNodes map[N]bool) <-chan N {
+	return g.ReachableNodesContext(context.Background(), frm, blockedNodes)
+}
+
+func (g *Graph[N, W]) CanReach(frm N, to N) bool {
+	found := false
+	g.WalkReachableNodes(frm, nil, func(node N) bool {
+		found = node == to
+		return !found
+	})
+	return found
 }
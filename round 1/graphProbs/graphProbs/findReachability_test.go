@@ -0,0 +1,38 @@
+package graphProbs
+
+import (
+	"context"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func chainGraph(n int) StringGraph {
+	edges := make([]StringEdge, n-1)
+	for i := range edges {
+		edges[i] = StringEdge{Frm: strconv.Itoa(i), To: strconv.Itoa(i + 1), Wt: 1}
+	}
+	return MkGraph(edges, nil)
+}
+
+func TestCancelledTraversalsDoNotLeak(t *testing.T) {
+	g := chainGraph(1000)
+	before := runtime.NumGoroutine()
+	for i := 0; i < 100; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		<-g.ReachableNodesContext(ctx, "0", nil)
+		<-g.NeighborsToBlockToEnsureUnreachabilityContext(ctx, "0", "999")
+		cancel()
+		if !g.CanReach("0", "1") {
+			t.Fatal("0 should reach 1")
+		}
+	}
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if runtime.NumGoroutine() > before {
+		t.Errorf("goroutines leaked, before: %d, after: %d", before, runtime.NumGoroutine())
+	}
+}
@@ -0,0 +1,359 @@
+package graphProbs
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// FrozenGraph is an immutable compressed sparse row copy of a Graph. Nodes are
+// interned to dense indices in sorted order, and the arcs leaving node i are
+// targets[offsets[i]:offsets[i+1]], sorted by target, with their weights at
+// the same positions in weights. The reverse arrays hold the arcs entering
+// each node the same way, keyed by source.
+type FrozenGraph[N Ordered, W Number] struct {
+	nodes   []N
+	index   map[N]int32
+	offsets []int
+	targets []int32
+	weights []W
+	// ids is only kept for multigraphs, parallel to targets.
+	ids            []EdgeId
+	reverseOffsets []int
+	sources        []int32
+	// negativeWeight is the error of Graph.CheckWeightsNonNegative, returned
+	// by the shortest path methods.
+	negativeWeight error
+}
+
+type csrArc[W Number] struct {
+	frm int32
+	to  int32
+	wt  W
+	id  EdgeId
+}
+
+// Freeze copies g into a FrozenGraph. Edges of an undirected graph are stored
+// in both directions, labels are dropped.
+func (g *Graph[N, W]) Freeze() *FrozenGraph[N, W] {
+	nodes := g.sortedNodes()
+	index := make(map[N]int32, len(nodes))
+	for i, node := range nodes {
+		index[node] = int32(i)
+	}
+	edges := g.Arcs()
+	arcs := make([]csrArc[W], len(edges))
+	for i, edge := range edges {
+		arcs[i] = csrArc[W]{frm: index[edge.Frm], to: index[edge.To], wt: edge.Wt, id: edge.Id}
+	}
+
+	f := &FrozenGraph[N, W]{
+		nodes:          nodes,
+		index:          index,
+		offsets:        make([]int, len(nodes)+1),
+		targets:        make([]int32, len(arcs)),
+		weights:        make([]W, len(arcs)),
+		reverseOffsets: make([]int, len(nodes)+1),
+		sources:        make([]int32, len(arcs)),
+		negativeWeight: g.CheckWeightsNonNegative(),
+	}
+	if g.multi {
+		f.ids = make([]EdgeId, len(arcs))
+	}
+	sort.Slice(arcs, func(i, j int) bool {
+		if arcs[i].frm != arcs[j].frm {
+			return arcs[i].frm < arcs[j].frm
+		}
+		if arcs[i].to != arcs[j].to {
+			return arcs[i].to < arcs[j].to
+		}
+		if arcs[i].wt != arcs[j].wt {
+			return arcs[i].wt < arcs[j].wt
+		}
+		return arcs[i].id < arcs[j].id
+	})
+	for p, arc := range arcs {
+		f.offsets[arc.frm+1]++
+		f.targets[p] = arc.to
+		f.weights[p] = arc.wt
+		if f.ids != nil {
+			f.ids[p] = arc.id
+		}
+	}
+	sort.Slice(arcs, func(i, j int) bool {
+		if arcs[i].to != arcs[j].to {
+			return arcs[i].to < arcs[j].to
+		}
+		return arcs[i].frm < arcs[j].frm
+	})
+	for p, arc := range arcs {
+		f.reverseOffsets[arc.to+1]++
+		f.sources[p] = arc.frm
+	}
+	for i := range nodes {
+		f.offsets[i+1] += f.offsets[i]
+		f.reverseOffsets[i+1] += f.reverseOffsets[i]
+	}
+	return f
+}
+
+func (f *FrozenGraph[N, W]) NumNodes() int {
+	return len(f.nodes)
+}
+
+// NumArcs counts edges of an undirected graph once per direction.
+func (f *FrozenGraph[N, W]) NumArcs() int {
+	return len(f.targets)
+}
+
+func (f *FrozenGraph[N, W]) IsValidNode(n N) bool {
+	_, ok := f.index[n]
+	return ok
+}
+
+// Nodes returns the nodes in sorted order, the caller must not modify them.
+func (f *FrozenGraph[N, W]) Nodes() []N {
+	return f.nodes
+}
+
+func (f *FrozenGraph[N, W]) arc(i int32, p int) Edge[N, W] {
+	edge := Edge[N, W]{Frm: f.nodes[i], To: f.nodes[f.targets[p]], Wt: f.weights[p]}
+	if f.ids != nil {
+		edge.Id = f.ids[p]
+	}
+	return edge
+}
+
+// Neighbors returns the edges leaving n sorted by target.
+func (f *FrozenGraph[N, W]) Neighbors(n N) []Edge[N, W] {
+	i, ok := f.index[n]
+	if !ok {
+		return nil
+	}
+	neighbors := make([]Edge[N, W], 0, f.offsets[i+1]-f.offsets[i])
+	for p := f.offsets[i]; p < f.offsets[i+1]; p++ {
+		neighbors = append(neighbors, f.arc(i, p))
+	}
+	return neighbors
+}
+
+func (f *FrozenGraph[N, W]) OutDegree(n N) int {
+	i, ok := f.index[n]
+	if !ok {
+		return 0
+	}
+	return f.offsets[i+1] - f.offsets[i]
+}
+
+func (f *FrozenGraph[N, W]) InDegree(n N) int {
+	i, ok := f.index[n]
+	if !ok {
+		return 0
+	}
+	return f.reverseOffsets[i+1] - f.reverseOffsets[i]
+}
+
+func (f *FrozenGraph[N, W]) blockedIndices(blockedNodes map[N]bool) []bool {
+	blocked := make([]bool, len(f.nodes))
+	for node := range blockedNodes {
+		i, ok := f.index[node]
+		if ok {
+			blocked[i] = true
+		}
+	}
+	return blocked
+}
+
+// walk is WalkReachableNodes over node indices.
+func (f *FrozenGraph[N, W]) walk(frm int32, blocked []bool, visit func(int32) bool) {
+	visited := make([]bool, len(f.nodes))
+	visited[frm] = true
+	queue := []int32{frm}
+	for head := 0; head < len(queue); head++ {
+		u := queue[head]
+		if !visit(u) {
+			return
+		}
+		for _, v := range f.targets[f.offsets[u]:f.offsets[u+1]] {
+			if blocked[v] || visited[v] {
+				continue
+			}
+			visited[v] = true
+			queue = append(queue, v)
+		}
+	}
+}
+
+// WalkReachableNodes behaves like Graph.WalkReachableNodes, visiting nodes in
+// breadth first order and the neighbors of each node in sorted order.
+func (f *FrozenGraph[N, W]) WalkReachableNodes(frm N, blockedNodes map[N]bool, visit func(N) bool) {
+	i, ok := f.index[frm]
+	if !ok {
+		visit(frm)
+		return
+	}
+	f.walk(i, f.blockedIndices(blockedNodes), func(u int32) bool { return visit(f.nodes[u]) })
+}
+
+func (f *FrozenGraph[N, W]) CanReach(frm N, to N) bool {
+	if frm == to {
+		return true
+	}
+	i, ok := f.index[frm]
+	if !ok {
+		return false
+	}
+	j, ok := f.index[to]
+	if !ok {
+		return false
+	}
+	found := false
+	f.walk(i, make([]bool, len(f.nodes)), func(u int32) bool {
+		found = u == j
+		return !found
+	})
+	return found
+}
+
+// WalkNeighborsToBlockToEnsureUnreachability behaves like the Graph method,
+// visiting the immediate parents of following that follower can reach without
+// going through following.
+func (f *FrozenGraph[N, W]) WalkNeighborsToBlockToEnsureUnreachability(follower N, following N, visit func(N) bool) {
+	i, ok := f.index[follower]
+	if !ok {
+		return
+	}
+	j, ok := f.index[following]
+	if !ok {
+		return
+	}
+	isParent := make([]bool, len(f.nodes))
+	for _, u := range f.sources[f.reverseOffsets[j]:f.reverseOffsets[j+1]] {
+		isParent[u] = true
+	}
+	blocked := make([]bool, len(f.nodes))
+	blocked[j] = true
+	f.walk(i, blocked, func(u int32) bool {
+		if isParent[u] {
+			return visit(f.nodes[u])
+		}
+		return true
+	})
+}
+
+// NeighborsToBlockToEnsureUnreachability returns the nodes of
+// WalkNeighborsToBlockToEnsureUnreachability in sorted order.
+func (f *FrozenGraph[N, W]) NeighborsToBlockToEnsureUnreachability(follower N, following N) []N {
+	toBlock := []N{}
+	f.WalkNeighborsToBlockToEnsureUnreachability(follower, following, func(node N) bool {
+		toBlock = append(toBlock, node)
+		return true
+	})
+	sortNodes(toBlock)
+	return toBlock
+}
+
+// dijkstra is Graph.dijkstra over node indices, settling nodes in (weight,
+// index) order, which is the same as (weight, node) order since nodes are
+// interned in sorted order. preds holds the arc position used to reach each
+// node, or -1.
+func (f *FrozenGraph[N, W]) dijkstra(start int32, end int32) ([]W, []int, []bool) {
+	dists := make([]W, len(f.nodes))
+	preds := make([]int, len(f.nodes))
+	for i := range preds {
+		preds[i] = -1
+	}
+	reached := make([]bool, len(f.nodes))
+	settled := make([]bool, len(f.nodes))
+	reached[start] = true
+	pq := pairHeap[int32, W]{pair[int32, W]{node: start, weight: 0}}
+	for len(pq) > 0 {
+		pr := heap.Pop(&pq).(pair[int32, W])
+		u := pr.node
+		if settled[u] {
+			continue
+		}
+		settled[u] = true
+		if u == end {
+			break
+		}
+		for p := f.offsets[u]; p < f.offsets[u+1]; p++ {
+			v := f.targets[p]
+			if settled[v] {
+				continue
+			}
+			dv := pr.weight + f.weights[p]
+			if !reached[v] || dv < dists[v] {
+				reached[v] = true
+				dists[v] = dv
+				preds[v] = p
+				heap.Push(&pq, pair[int32, W]{node: v, weight: dv})
+			}
+		}
+	}
+	return dists, preds, reached
+}
+
+// ShortestPath behaves like Graph.ShortestPath, weights must not be negative.
+func (f *FrozenGraph[N, W]) ShortestPath(start N, end N) *Path[N, W] {
+	i, ok := f.index[start]
+	if !ok {
+		return nil
+	}
+	j, ok := f.index[end]
+	if !ok {
+		return nil
+	}
+	dists, preds, reached := f.dijkstra(i, j)
+	if !reached[j] {
+		return nil
+	}
+	edges := []Edge[N, W]{}
+	for v := j; v != i; {
+		p := preds[v]
+		u := f.sourceOf(p)
+		edges = append(edges, f.arc(u, p))
+		v = u
+	}
+	for a, b := 0, len(edges)-1; a < b; a, b = a+1, b-1 {
+		edges[a], edges[b] = edges[b], edges[a]
+	}
+	return &Path[N, W]{Start: start, Edges: edges, Wt: dists[j]}
+}
+
+// sourceOf finds the row holding arc position p by binary search.
+func (f *FrozenGraph[N, W]) sourceOf(p int) int32 {
+	lo, hi := 0, len(f.nodes)-1
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if f.offsets[mid] <= p {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return int32(lo)
+}
+
+func (f *FrozenGraph[N, W]) ShortestTime(start N, end N) *W {
+	path := f.ShortestPath(start, end)
+	if path == nil {
+		return nil
+	}
+	return &path.Wt
+}
+
+// CheckedShortestPath behaves like Graph.CheckedShortestPath, the check was
+// done once by Freeze.
+func (f *FrozenGraph[N, W]) CheckedShortestPath(start N, end N) (*Path[N, W], error) {
+	if f.negativeWeight != nil {
+		return nil, f.negativeWeight
+	}
+	return f.ShortestPath(start, end), nil
+}
+
+func (f *FrozenGraph[N, W]) CheckedShortestTime(start N, end N) (*W, error) {
+	if f.negativeWeight != nil {
+		return nil, f.negativeWeight
+	}
+	return f.ShortestTime(start, end), nil
+}
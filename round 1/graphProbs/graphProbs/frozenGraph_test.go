@@ -0,0 +1,136 @@
+package graphProbs
+
+import (
+	"math/rand"
+	"runtime"
+	"strconv"
+	"testing"
+)
+
+func TestFrozenGraphMatchesGraph(t *testing.T) {
+	rng := rand.New(rand.NewSource(23))
+	for round := 0; round < 50; round++ {
+		g := randomGraph(rng, 30, 60, 10)
+		if round%2 == 1 {
+			g = MkUndirectedGraph(g.Edges(), g.Nodes())
+		}
+		f := g.Freeze()
+		if f.NumNodes() != 30 || f.NumArcs() != len(g.Arcs()) {
+			t.Fatalf("expected 30 nodes and %d arcs, got %d and %d", len(g.Arcs()), f.NumNodes(), f.NumArcs())
+		}
+		for i := 0; i < 30; i++ {
+			start, end := strconv.Itoa(rng.Intn(30)), strconv.Itoa(rng.Intn(30))
+			if g.CanReach(start, end) != f.CanReach(start, end) {
+				t.Errorf("%s -> %s: CanReach differs", start, end)
+			}
+			path, err := f.CheckedShortestPath(start, end)
+			assertSameWeight(t, &g, start, end, path, err)
+			if path != nil && len(path.Edges) > 0 && (path.Edges[0].Frm != start || path.Edges[len(path.Edges)-1].To != end) {
+				t.Errorf("%s -> %s: path has the wrong endpoints %v", start, end, path.Edges)
+			}
+
+			expected := map[Node]bool{}
+			for node := range g.NeighborsToBlockToEnsureUnreachability(start, end) {
+				expected[node] = true
+			}
+			toBlock := f.NeighborsToBlockToEnsureUnreachability(start, end)
+			if len(toBlock) != len(expected) {
+				t.Errorf("%s -> %s: expected to block %v, got %v", start, end, expected, toBlock)
+			}
+			for _, node := range toBlock {
+				if !expected[node] {
+					t.Errorf("%s -> %s: unexpected node to block %s", start, end, node)
+				}
+			}
+		}
+	}
+}
+
+func benchmarkGraph(numNodes int, numEdges int) StringGraph {
+	return randomGraph(rand.New(rand.NewSource(1)), numNodes, numEdges, 100)
+}
+
+func heapInUse() uint64 {
+	runtime.GC()
+	stats := runtime.MemStats{}
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
+
+// BenchmarkRetainedMemory reports the bytes kept alive per edge, the time per
+// operation is the time to build the representation.
+func BenchmarkRetainedMemory(b *testing.B) {
+	const numNodes, numEdges = 20000, 200000
+	nodes := make([]Node, numNodes)
+	for i := range nodes {
+		nodes[i] = strconv.Itoa(i)
+	}
+	rng := rand.New(rand.NewSource(1))
+	edges := make([]StringEdge, numEdges)
+	for i := range edges {
+		edges[i] = StringEdge{Frm: nodes[rng.Intn(numNodes)], To: nodes[rng.Intn(numNodes)], Wt: Weight(rng.Intn(100))}
+	}
+	b.Run("Graph", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			before := heapInUse()
+			g := MkGraph(edges, nodes)
+			b.ReportMetric(float64(heapInUse()-before)/numEdges, "bytes/edge")
+			runtime.KeepAlive(g)
+		}
+	})
+	g := MkGraph(edges, nodes)
+	b.Run("FrozenGraph", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			before := heapInUse()
+			f := g.Freeze()
+			b.ReportMetric(float64(heapInUse()-before)/numEdges, "bytes/edge")
+			runtime.KeepAlive(f)
+		}
+	})
+}
+
+func BenchmarkCanReach(b *testing.B) {
+	g := benchmarkGraph(20000, 60000)
+	f := g.Freeze()
+	b.Run("Graph", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			g.CanReach(strconv.Itoa(i%20000), strconv.Itoa((i+1)%20000))
+		}
+	})
+	b.Run("FrozenGraph", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			f.CanReach(strconv.Itoa(i%20000), strconv.Itoa((i+1)%20000))
+		}
+	})
+}
+
+func BenchmarkShortestTime(b *testing.B) {
+	g := benchmarkGraph(20000, 60000)
+	f := g.Freeze()
+	b.Run("Graph", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			g.ShortestTime(strconv.Itoa(i%20000), strconv.Itoa((i+1)%20000))
+		}
+	})
+	b.Run("FrozenGraph", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			f.ShortestTime(strconv.Itoa(i%20000), strconv.Itoa((i+1)%20000))
+		}
+	})
+}
+
+func BenchmarkNeighborsToBlock(b *testing.B) {
+	g := benchmarkGraph(20000, 60000)
+	f := g.Freeze()
+	b.Run("Graph", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for range g.NeighborsToBlockToEnsureUnreachability(strconv.Itoa(i%20000), strconv.Itoa((i+1)%20000)) {
+			}
+		}
+	})
+	b.Run("FrozenGraph", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			f.NeighborsToBlockToEnsureUnreachability(strconv.Itoa(i%20000), strconv.Itoa((i+1)%20000))
+		}
+	})
+}
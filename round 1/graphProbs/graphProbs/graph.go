@@ -1,25 +1,109 @@
 package graphProbs
 
+import (
+	"fmt"
+	"sort"
+)
+
+// Ordered is the constraint on node types, nodes need an order so that ties
+// are always broken the same way and results do not depend on map iteration.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+// Number is the constraint on weight types. Shortest paths and flows need
+// weights that are not negative, the Checked shortest path variants and flows
+// return a NegativeWeightError otherwise, BellmanFord allows negative weights.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Node and Weight are the node and weight types of StringGraph, the graph read
+// from problem files.
 type Node = string
 type Weight = uint
-type associations = map[Node]Weight
-type adjacencyMatrix = map[Node]associations
 
-type Graph struct {
-	adjacencyMatrix adjacencyMatrix
-	nodes           map[Node]bool
+type StringGraph = Graph[Node, Weight]
+type StringEdge = Edge[Node, Weight]
+type StringPath = Path[Node, Weight]
+
+// SignedWeight and the types built on it allow negative weights, see
+// BellmanFord.
+type SignedWeight = int
+type SignedGraph = Graph[Node, SignedWeight]
+type SignedEdge = Edge[Node, SignedWeight]
+type SignedPath = Path[Node, SignedWeight]
+
+type Graph[N Ordered, W Number] struct {
+	adjacencyMatrix map[N]map[N]W
+	// reverseAdjacencyMatrix holds the same edges keyed by To, then Frm.
+	reverseAdjacencyMatrix map[N]map[N]W
+	nodes                  map[N]bool
+	// undirected graphs store every edge in both directions of both matrices.
+	undirected bool
+	// multi graphs keep every parallel edge in parallelEdges and its reverse,
+	// in insertion order and oriented from the outer key to the inner one,
+	// while the adjacency matrices hold the cheapest weight of each pair.
+	multi                bool
+	parallelEdges        map[N]map[N][]Edge[N, W]
+	reverseParallelEdges map[N]map[N][]Edge[N, W]
+	edgesById            map[EdgeId]Edge[N, W]
+	edgeLabels           map[EdgeId][]string
+	nextEdgeId           *EdgeId
+	// transposed is set on views returned by Transpose, edgesById keeps the
+	// orientation of the underlying graph.
+	transposed bool
+	// version is shared between copies of a Graph, like the maps are, and is
+	// bumped on every mutation so derived indexes can tell they are stale.
+	version *uint64
+	// negativeEdges counts the edges with a negative weight, shared like
+	// version, so that CheckWeightsNonNegative need not scan every edge.
+	negativeEdges *int
+}
+
+type Edge[N Ordered, W Number] struct {
+	Frm N
+	To  N
+	Wt  W
+	// Id is only kept by multigraphs, where it tells apart the parallel edges
+	// joining the same pair of nodes.
+	Id EdgeId
 }
 
-type Edge struct {
-	Frm Node
-	To  Node
-	Wt  Weight
+type GraphOptions struct {
+	Undirected bool
+	Multi      bool
 }
 
-func MkGraph(edges []Edge, nodes []Node) Graph {
-	g := Graph{
-		adjacencyMatrix: adjacencyMatrix{},
-		nodes:           map[Node]bool{},
+func MkGraph[N Ordered, W Number](edges []Edge[N, W], nodes []N) Graph[N, W] {
+	return MkGraphWithOptions(GraphOptions{}, edges, nodes)
+}
+
+func MkUndirectedGraph[N Ordered, W Number](edges []Edge[N, W], nodes []N) Graph[N, W] {
+	return MkGraphWithOptions(GraphOptions{Undirected: true}, edges, nodes)
+}
+
+func MkGraphWithOptions[N Ordered, W Number](opts GraphOptions, edges []Edge[N, W], nodes []N) Graph[N, W] {
+	g := Graph[N, W]{
+		adjacencyMatrix:        map[N]map[N]W{},
+		reverseAdjacencyMatrix: map[N]map[N]W{},
+		nodes:                  map[N]bool{},
+		undirected:             opts.Undirected,
+		version:                new(uint64),
+		negativeEdges:          new(int),
+	}
+	if opts.Multi {
+		g.multi = true
+		g.parallelEdges = map[N]map[N][]Edge[N, W]{}
+		g.reverseParallelEdges = map[N]map[N][]Edge[N, W]{}
+		g.edgesById = map[EdgeId]Edge[N, W]{}
+		g.edgeLabels = map[EdgeId][]string{}
+		g.nextEdgeId = new(EdgeId)
+		g.reserveEdgeIds(edges)
 	}
 	for _, edge := range edges {
 		g.AddEdge(edge)
@@ -30,31 +114,71 @@ func MkGraph(edges []Edge, nodes []Node) Graph {
 	return g
 }
 
-func (g *Graph) AddEdge(e Edge) {
+func (g *Graph[N, W]) AddEdge(e Edge[N, W]) {
+	g.addEdge(e)
+}
+
+// addEdge returns the id e was stored under, which is 0 unless g is a
+// multigraph.
+func (g *Graph[N, W]) addEdge(e Edge[N, W]) EdgeId {
+	*g.version += 1
 	g.nodes[e.Frm] = true
 	g.nodes[e.To] = true
-	assocs, ok := g.adjacencyMatrix[e.Frm]
-	if !ok {
-		g.adjacencyMatrix[e.Frm] = associations{e.To: e.Wt}
+	if g.multi {
+		return g.addParallelEdge(e)
+	}
+	old, ok := g.adjacencyMatrix[e.Frm][e.To]
+	if ok {
+		g.countNegative(old, -1)
+	}
+	g.countNegative(e.Wt, 1)
+	addAssociation(g.adjacencyMatrix, e.Frm, e.To, e.Wt)
+	addAssociation(g.reverseAdjacencyMatrix, e.To, e.Frm, e.Wt)
+	if g.undirected {
+		addAssociation(g.adjacencyMatrix, e.To, e.Frm, e.Wt)
+		addAssociation(g.reverseAdjacencyMatrix, e.Frm, e.To, e.Wt)
+	}
+	return 0
+}
+
+// countNegative adds delta to the count of negative edges if wt is negative.
+func (g *Graph[N, W]) countNegative(wt W, delta int) {
+	if wt < 0 {
+		*g.negativeEdges += delta
+	}
+}
+
+func addAssociation[N Ordered, W Number](m map[N]map[N]W, u N, v N, wt W) {
+	assocs := m[u]
+	if assocs == nil {
+		m[u] = map[N]W{v: wt}
 		return
 	}
-	assocs[e.To] = e.Wt
+	assocs[v] = wt
 }
 
-func (g *Graph) AddNode(n Node) {
+func (g *Graph[N, W]) AddNode(n N) {
+	*g.version += 1
 	g.nodes[n] = true
 	_, ok := g.adjacencyMatrix[n]
 	if !ok {
 		g.adjacencyMatrix[n] = nil
 	}
+	_, ok = g.reverseAdjacencyMatrix[n]
+	if !ok {
+		g.reverseAdjacencyMatrix[n] = nil
+	}
 }
 
-func (g *Graph) Neighbors(n Node) []Edge {
+func (g *Graph[N, W]) Neighbors(n N) []Edge[N, W] {
+	if g.multi {
+		return flattenParallel(g.parallelEdges[n], false)
+	}
 	assocs, ok := g.adjacencyMatrix[n]
 	if !ok {
 		return nil
 	}
-	neighbors := make([]Edge, len(assocs))
+	neighbors := make([]Edge[N, W], len(assocs))
 	i := 0
 	for node := range assocs {
 		neighbors[i].Frm = n
@@ -65,13 +189,25 @@ func (g *Graph) Neighbors(n Node) []Edge {
 	return neighbors
 }
 
-func (g *Graph) IsValidNode(n Node) bool {
-	_, ok := g.adjacencyMatrix[n]
+func (g *Graph[N, W]) InNeighbors(n N) []Edge[N, W] {
+	if g.multi {
+		return flattenParallel(g.reverseParallelEdges[n], true)
+	}
+	assocs := g.reverseAdjacencyMatrix[n]
+	neighbors := make([]Edge[N, W], 0, len(assocs))
+	for node, wt := range assocs {
+		neighbors = append(neighbors, Edge[N, W]{Frm: node, To: n, Wt: wt})
+	}
+	return neighbors
+}
+
+func (g *Graph[N, W]) IsValidNode(n N) bool {
+	_, ok := g.nodes[n]
 	return ok
 }
 
-func (g *Graph) Nodes() []Node {
-	nodes := make([]Node, len(g.nodes))
+func (g *Graph[N, W]) Nodes() []N {
+	nodes := make([]N, len(g.nodes))
 	i := 0
 	for node := range g.nodes {
 		nodes[i] = node
@@ -80,24 +216,121 @@ func (g *Graph) Nodes() []Node {
 	return nodes
 }
 
-func (g *Graph) Edges() []Edge {
-	edges := []Edge{}
+func (g *Graph[N, W]) IsUndirected() bool {
+	return g.undirected
+}
+
+// Edges returns every edge once, for undirected graphs as Frm <= To.
+func (g *Graph[N, W]) Edges() []Edge[N, W] {
+	edges := []Edge[N, W]{}
+	if g.multi {
+		for u := range g.parallelEdges {
+			for _, edge := range g.Neighbors(u) {
+				if !g.undirected || edge.Frm <= edge.To {
+					edges = append(edges, edge)
+				}
+			}
+		}
+		return edges
+	}
 	for u := range g.adjacencyMatrix {
 		assocs := g.adjacencyMatrix[u]
 		for v := range assocs {
-			edges = append(edges, Edge{Frm: u, To: v, Wt: assocs[v]})
+			if g.undirected && v < u {
+				continue
+			}
+			edges = append(edges, Edge[N, W]{Frm: u, To: v, Wt: assocs[v]})
 		}
 	}
 	return edges
 }
 
-func (g *Graph) ImmediateParents(n Node) map[Node]bool {
-	immediateParents := map[Node]bool{}
-	for u := range g.adjacencyMatrix {
-		_, ok := g.adjacencyMatrix[u][n]
-		if ok {
-			immediateParents[u] = true
-		}
+// Arcs returns every edge in each direction it can be traversed, so for
+// undirected graphs both u -> v and v -> u, and for directed ones the same as
+// Edges.
+func (g *Graph[N, W]) Arcs() []Edge[N, W] {
+	if !g.undirected {
+		return g.Edges()
+	}
+	arcs := []Edge[N, W]{}
+	for u := range g.nodes {
+		arcs = append(arcs, g.Neighbors(u)...)
+	}
+	return arcs
+}
+
+func (g *Graph[N, W]) OutDegree(n N) int {
+	if g.multi {
+		return countParallel(g.parallelEdges[n])
+	}
+	return len(g.adjacencyMatrix[n])
+}
+
+func (g *Graph[N, W]) InDegree(n N) int {
+	if g.multi {
+		return countParallel(g.reverseParallelEdges[n])
+	}
+	return len(g.reverseAdjacencyMatrix[n])
+}
+
+func (g *Graph[N, W]) ImmediateParents(n N) map[N]bool {
+	immediateParents := make(map[N]bool, len(g.reverseAdjacencyMatrix[n]))
+	for u := range g.reverseAdjacencyMatrix[n] {
+		immediateParents[u] = true
 	}
 	return immediateParents
 }
+
+// Transpose returns a view of g with every edge reversed. It shares g's maps
+// rather than copying them, so it stays in sync with g, and edges added
+// through the view show up reversed in g. An undirected graph is its own
+// transpose.
+func (g *Graph[N, W]) Transpose() Graph[N, W] {
+	return Graph[N, W]{
+		adjacencyMatrix:        g.reverseAdjacencyMatrix,
+		reverseAdjacencyMatrix: g.adjacencyMatrix,
+		nodes:                  g.nodes,
+		undirected:             g.undirected,
+		multi:                  g.multi,
+		parallelEdges:          g.reverseParallelEdges,
+		reverseParallelEdges:   g.parallelEdges,
+		edgesById:              g.edgesById,
+		edgeLabels:             g.edgeLabels,
+		nextEdgeId:             g.nextEdgeId,
+		transposed:             !g.transposed,
+		version:                g.version,
+		negativeEdges:          g.negativeEdges,
+	}
+}
+
+func (g *Graph[N, W]) Version() uint64 {
+	return *g.version
+}
+
+func (g *Graph[N, W]) sortedNodes() []N {
+	nodes := g.Nodes()
+	sortNodes(nodes)
+	return nodes
+}
+
+func sortNodes[N Ordered](nodes []N) {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+}
+
+// formatNodes returns the nodes as printed by fmt, for error messages and
+// keys that need a string.
+func formatNodes[N Ordered](nodes []N) []string {
+	formatted := make([]string, len(nodes))
+	for i, node := range nodes {
+		formatted[i] = fmt.Sprint(node)
+	}
+	return formatted
+}
+
+func nodeIndices[N Ordered](nodes []N) map[N]int {
+	indices := make(map[N]int, len(nodes))
+	for i, node := range nodes {
+		indices[node] = i
+	}
+	return indices
+}
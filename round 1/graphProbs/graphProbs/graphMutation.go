@@ -0,0 +1,122 @@
+package graphProbs
+
+import "fmt"
+
+type NodeNotFoundError[N Ordered] struct {
+	Node N
+}
+
+func (e *NodeNotFoundError[N]) Error() string {
+	return fmt.Sprintf("node %v is not part of the graph", e.Node)
+}
+
+type EdgeNotFoundError[N Ordered] struct {
+	Frm N
+	To  N
+}
+
+func (e *EdgeNotFoundError[N]) Error() string {
+	return fmt.Sprintf("edge %v -> %v is not part of the graph", e.Frm, e.To)
+}
+
+func (g *Graph[N, W]) checkEdge(frm N, to N) error {
+	for _, node := range []N{frm, to} {
+		if !g.IsValidNode(node) {
+			return &NodeNotFoundError[N]{Node: node}
+		}
+	}
+	_, ok := g.adjacencyMatrix[frm][to]
+	if !ok {
+		return &EdgeNotFoundError[N]{Frm: frm, To: to}
+	}
+	return nil
+}
+
+// RemoveEdge deletes the edge frm -> to, on a multigraph every parallel edge
+// between the pair is deleted, RemoveEdgeById deletes a single one.
+func (g *Graph[N, W]) RemoveEdge(frm N, to N) error {
+	err := g.checkEdge(frm, to)
+	if err != nil {
+		return err
+	}
+	*g.version += 1
+	if g.multi {
+		g.removeParallelPair(frm, to)
+		return nil
+	}
+	g.countNegative(g.adjacencyMatrix[frm][to], -1)
+	delete(g.adjacencyMatrix[frm], to)
+	delete(g.reverseAdjacencyMatrix[to], frm)
+	if g.undirected {
+		delete(g.adjacencyMatrix[to], frm)
+		delete(g.reverseAdjacencyMatrix[frm], to)
+	}
+	return nil
+}
+
+// UpdateWeight changes the weight of the edge frm -> to. On a multigraph it
+// fails with a *ParallelEdgesError when several edges join the pair, use
+// UpdateEdgeWeight instead.
+func (g *Graph[N, W]) UpdateWeight(frm N, to N, wt W) error {
+	err := g.checkEdge(frm, to)
+	if err != nil {
+		return err
+	}
+	if g.multi {
+		parallel := g.parallelEdges[frm][to]
+		if len(parallel) > 1 {
+			ids := make([]EdgeId, len(parallel))
+			for i, edge := range parallel {
+				ids[i] = edge.Id
+			}
+			return &ParallelEdgesError[N]{Frm: frm, To: to, Ids: ids}
+		}
+		return g.UpdateEdgeWeight(parallel[0].Id, wt)
+	}
+	*g.version += 1
+	g.countNegative(g.adjacencyMatrix[frm][to], -1)
+	g.countNegative(wt, 1)
+	g.adjacencyMatrix[frm][to] = wt
+	g.reverseAdjacencyMatrix[to][frm] = wt
+	if g.undirected {
+		g.adjacencyMatrix[to][frm] = wt
+		g.reverseAdjacencyMatrix[frm][to] = wt
+	}
+	return nil
+}
+
+// RemoveNode deletes n along with every edge into or out of it.
+func (g *Graph[N, W]) RemoveNode(n N) error {
+	if !g.IsValidNode(n) {
+		return &NodeNotFoundError[N]{Node: n}
+	}
+	*g.version += 1
+	if g.multi {
+		for to := range g.adjacencyMatrix[n] {
+			g.removeParallelPair(n, to)
+		}
+		for frm := range g.reverseAdjacencyMatrix[n] {
+			g.removeParallelPair(frm, n)
+		}
+		delete(g.parallelEdges, n)
+		delete(g.reverseParallelEdges, n)
+	}
+	for to, wt := range g.adjacencyMatrix[n] {
+		if !g.multi {
+			g.countNegative(wt, -1)
+		}
+		delete(g.reverseAdjacencyMatrix[to], n)
+	}
+	for frm, wt := range g.reverseAdjacencyMatrix[n] {
+		// A self loop, and every edge of an undirected graph, was counted
+		// among the edges out of n.
+		if !g.multi && !g.undirected && frm != n {
+			g.countNegative(wt, -1)
+		}
+		delete(g.adjacencyMatrix[frm], n)
+	}
+	delete(g.adjacencyMatrix, n)
+	delete(g.reverseAdjacencyMatrix, n)
+	delete(g.nodes, n)
+	return nil
+}
@@ -0,0 +1,185 @@
+package graphProbs
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// checkConsistent verifies that the node set, both adjacency matrices and, for
+// multigraphs, the parallel edge and id maps all describe the same edges.
+func checkConsistent[N Ordered, W Number](t *testing.T, g *Graph[N, W]) {
+	t.Helper()
+	for _, m := range []map[N]map[N]W{g.adjacencyMatrix, g.reverseAdjacencyMatrix} {
+		for u, assocs := range m {
+			for v := range assocs {
+				if !g.nodes[u] || !g.nodes[v] {
+					t.Fatalf("edge between %v and %v refers to a removed node", u, v)
+				}
+			}
+		}
+	}
+	for u, assocs := range g.adjacencyMatrix {
+		for v, wt := range assocs {
+			reverse, ok := g.reverseAdjacencyMatrix[v][u]
+			if !ok || reverse != wt {
+				t.Fatalf("edge %v -> %v with weight %v is missing from the reverse matrix", u, v, wt)
+			}
+			if g.undirected && g.adjacencyMatrix[v][u] != wt {
+				t.Fatalf("undirected edge %v -- %v is only stored one way", u, v)
+			}
+		}
+	}
+	for v, assocs := range g.reverseAdjacencyMatrix {
+		for u := range assocs {
+			_, ok := g.adjacencyMatrix[u][v]
+			if !ok {
+				t.Fatalf("reverse matrix holds %v -> %v that the matrix does not", u, v)
+			}
+		}
+	}
+	if !g.multi {
+		return
+	}
+	ids := map[EdgeId]bool{}
+	for u, assocs := range g.parallelEdges {
+		for v, parallel := range assocs {
+			wt, ok := g.adjacencyMatrix[u][v]
+			if len(parallel) == 0 || !ok {
+				t.Fatalf("parallel edges %v between %v and %v do not match the matrix", parallel, u, v)
+			}
+			for _, edge := range parallel {
+				if edge.Wt < wt {
+					t.Fatalf("matrix weight %v of %v -> %v is not the cheapest of %v", wt, u, v, parallel)
+				}
+				stored, ok := g.EdgeById(edge.Id)
+				if !ok || stored.Wt != edge.Wt {
+					t.Fatalf("edge %v is not stored under its id", edge)
+				}
+				ids[edge.Id] = true
+			}
+		}
+	}
+	if len(ids) != len(g.edgesById) {
+		t.Fatalf("expected %d ids, the parallel edges use %d", len(g.edgesById), len(ids))
+	}
+	for id := range g.edgeLabels {
+		if !ids[id] {
+			t.Fatalf("labels kept for removed edge %d", id)
+		}
+	}
+}
+
+func TestRemoveAndUpdate(t *testing.T) {
+	for _, undirected := range []bool{false, true} {
+		g := MkGraphWithOptions(GraphOptions{Undirected: undirected}, []StringEdge{
+			{Frm: "a", To: "b", Wt: 1},
+			{Frm: "b", To: "c", Wt: 2},
+			{Frm: "c", To: "a", Wt: 3},
+			{Frm: "c", To: "c", Wt: 4},
+		}, []Node{"d"})
+
+		version := g.Version()
+		var nodeErr *NodeNotFoundError[Node]
+		var edgeErr *EdgeNotFoundError[Node]
+		if !errors.As(g.RemoveEdge("a", "x"), &nodeErr) || nodeErr.Node != "x" {
+			t.Errorf("undirected=%v: expected a NodeNotFoundError for x", undirected)
+		}
+		if !errors.As(g.UpdateWeight("a", "d", 5), &edgeErr) || edgeErr.Frm != "a" || edgeErr.To != "d" {
+			t.Errorf("undirected=%v: expected an EdgeNotFoundError for a -> d", undirected)
+		}
+		if !errors.As(g.RemoveNode("x"), &nodeErr) {
+			t.Errorf("undirected=%v: expected a NodeNotFoundError from RemoveNode", undirected)
+		}
+		if g.Version() != version {
+			t.Errorf("undirected=%v: expected failed mutations to leave the version alone", undirected)
+		}
+
+		// b -> a only exists when the graph is undirected.
+		err := g.UpdateWeight("b", "a", 7)
+		if undirected != (err == nil) {
+			t.Errorf("undirected=%v: unexpected UpdateWeight(b, a) error %v", undirected, err)
+		}
+		if undirected && (g.adjacencyMatrix["a"]["b"] != 7 || g.Version() == version) {
+			t.Errorf("undirected=%v: expected a -- b to weigh 7 and the version to move", undirected)
+		}
+		checkConsistent(t, &g)
+
+		version = g.Version()
+		err = g.RemoveEdge("b", "c")
+		if err != nil || g.CanReach("b", "c") != undirected || g.Version() == version {
+			t.Errorf("undirected=%v: expected b -> c to be gone, got %v %v", undirected, err, g.Edges())
+		}
+		checkConsistent(t, &g)
+
+		version = g.Version()
+		err = g.RemoveNode("c")
+		if err != nil || g.IsValidNode("c") || len(g.Edges()) != 1 || g.InDegree("b") != 1 || g.Version() == version {
+			t.Errorf("undirected=%v: expected only a -> b left, got %v %v", undirected, err, g.Edges())
+		}
+		checkConsistent(t, &g)
+		if !errors.As(g.RemoveEdge("c", "a"), &nodeErr) {
+			t.Errorf("undirected=%v: expected the removed node to be unknown", undirected)
+		}
+		err = g.RemoveNode("d")
+		if err != nil || len(g.Nodes()) != 2 {
+			t.Errorf("undirected=%v: expected the isolated node to be removed, got %v %v", undirected, err, g.Nodes())
+		}
+	}
+}
+
+func TestRemoveNodeOfMultiGraph(t *testing.T) {
+	for _, undirected := range []bool{false, true} {
+		g := MkGraphWithOptions[Node, Weight](GraphOptions{Undirected: undirected, Multi: true}, nil, nil)
+		ab := g.AddLabeledEdge(StringEdge{Frm: "a", To: "b", Wt: 1}, "first")
+		g.AddLabeledEdge(StringEdge{Frm: "a", To: "b", Wt: 2}, "second")
+		ba := g.AddLabeledEdge(StringEdge{Frm: "b", To: "a", Wt: 3}, "back")
+		bc := g.AddLabeledEdge(StringEdge{Frm: "b", To: "c", Wt: 4}, "on")
+		g.AddEdge(StringEdge{Frm: "b", To: "b", Wt: 5})
+
+		err := g.UpdateWeight("b", "c", 6)
+		edge, _ := g.EdgeById(bc)
+		if err != nil || edge.Wt != 6 {
+			t.Errorf("undirected=%v: expected the single edge b -> c to be updated, got %v %v", undirected, edge, err)
+		}
+		checkConsistent(t, &g)
+
+		err = g.RemoveNode("a")
+		if err != nil || len(g.Edges()) != 2 || g.OutDegree("b") != 2 {
+			t.Errorf("undirected=%v: expected b -> b and b -> c left, got %v %v", undirected, err, g.Edges())
+		}
+		for _, id := range []EdgeId{ab, ba} {
+			_, ok := g.EdgeById(id)
+			if ok || g.Labels(id) != nil {
+				t.Errorf("undirected=%v: expected edge %d and its labels to be gone", undirected, id)
+			}
+		}
+		if g.Labels(bc)[0] != "on" {
+			t.Errorf("undirected=%v: expected the labels of b -> c to be kept", undirected)
+		}
+		checkConsistent(t, &g)
+	}
+}
+
+func TestRandomMutationsKeepGraphConsistent(t *testing.T) {
+	rng := rand.New(rand.NewSource(15))
+	for _, opts := range []GraphOptions{{}, {Undirected: true}, {Multi: true}, {Undirected: true, Multi: true}} {
+		g := MkGraphWithOptions[int, int](opts, nil, nil)
+		for step := 0; step < 2000; step++ {
+			u, v := rng.Intn(6), rng.Intn(6)
+			switch rng.Intn(5) {
+			case 0, 1:
+				g.AddLabeledEdge(Edge[int, int]{Frm: u, To: v, Wt: rng.Intn(10)}, "label")
+			case 2:
+				g.UpdateWeight(u, v, rng.Intn(10))
+			case 3:
+				g.RemoveEdge(u, v)
+			case 4:
+				if rng.Intn(3) == 0 {
+					g.RemoveNode(u)
+				}
+			}
+			checkConsistent(t, &g)
+		}
+	}
+}
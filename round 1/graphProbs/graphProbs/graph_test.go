@@ -0,0 +1,272 @@
+package graphProbs
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestIntegerNodesAndFloatWeights(t *testing.T) {
+	g := MkGraph([]Edge[int64, float64]{
+		{Frm: 10, To: 2, Wt: 0.5},
+		{Frm: 2, To: 30, Wt: 0.25},
+		{Frm: 10, To: 30, Wt: 1},
+	}, []int64{40})
+	path := g.ShortestPath(10, 30)
+	if path == nil || path.Wt != 0.75 || len(path.Edges) != 2 {
+		t.Fatalf("expected 10 -> 2 -> 30 with weight 0.75, got %v", path)
+	}
+	if !g.CanReach(10, 30) || g.CanReach(10, 40) {
+		t.Error("expected 30 and not 40 to be reachable from 10")
+	}
+	order, err := g.LexicographicTopologicalSort()
+	if err != nil || len(order) != 4 || order[0] != 10 || order[1] != 2 {
+		t.Errorf("expected numeric ordering 10 2 30 40, got %v %v", order, err)
+	}
+	condensed, _ := g.Condensation()
+	if len(condensed.Edges()) != 3 {
+		t.Errorf("expected the condensation to keep all 3 edges, got %v", condensed.Edges())
+	}
+}
+
+func TestNegativeWeights(t *testing.T) {
+	g := MkGraph([]Edge[Node, int]{
+		{Frm: "a", To: "b", Wt: 5},
+		{Frm: "a", To: "c", Wt: 1},
+		{Frm: "b", To: "c", Wt: -10},
+	}, nil)
+	var negativeErr *NegativeWeightError[Node, int]
+	_, err := g.CheckedShortestTime("a", "c")
+	if !errors.As(err, &negativeErr) || negativeErr.Edge.Frm != "b" || negativeErr.Edge.Wt != -10 {
+		t.Errorf("expected a NegativeWeightError for b -> c, got %v", err)
+	}
+	_, err = g.CheckedShortestTimesFrom("a")
+	if !errors.As(err, &negativeErr) {
+		t.Errorf("expected CheckedShortestTimesFrom to return a NegativeWeightError, got %v", err)
+	}
+	_, err = g.AStar("a", "c", func(Node) int { return 0 })
+	if !errors.As(err, &negativeErr) {
+		t.Errorf("expected AStar to return a NegativeWeightError, got %v", err)
+	}
+	_, err = g.Freeze().CheckedShortestPath("a", "c")
+	if !errors.As(err, &negativeErr) {
+		t.Errorf("expected FrozenGraph to return a NegativeWeightError, got %v", err)
+	}
+	_, err = g.MinimumWeightedEdgeCut("a", "c")
+	if !errors.As(err, &negativeErr) {
+		t.Errorf("expected MinimumWeightedEdgeCut to return a NegativeWeightError, got %v", err)
+	}
+	_, err = g.MinimumEdgeCut("a", "c")
+	if err != nil {
+		t.Errorf("expected the unweighted cut to ignore weights, got %v", err)
+	}
+
+	tree, err := g.BellmanFord("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := tree.PathTo("c")
+	if path == nil || path.Wt != -5 || len(path.Edges) != 2 {
+		t.Errorf("expected a -> b -> c with weight -5, got %v", path)
+	}
+}
+
+func TestBridgesOfDirectedTwoCycle(t *testing.T) {
+	g := MkGraph([]StringEdge{
+		{Frm: "a", To: "b", Wt: 1},
+		{Frm: "b", To: "a", Wt: 2},
+		{Frm: "b", To: "c", Wt: 3},
+	}, nil)
+	bridges := g.Bridges()
+	if len(bridges) != 1 || bridges[0].Frm != "b" || bridges[0].To != "c" {
+		t.Errorf("expected only b -> c as bridge, a -> b has b -> a as spare, got %v", bridges)
+	}
+}
+
+func TestTopologicalSortOfUndirectedGraph(t *testing.T) {
+	g := MkUndirectedGraph([]StringEdge{{Frm: "a", To: "b", Wt: 1}}, nil)
+	var undirectedErr *UndirectedGraphError
+	_, err := g.TopologicalSort()
+	if !errors.As(err, &undirectedErr) {
+		t.Errorf("expected an UndirectedGraphError, got %v", err)
+	}
+	_, err = g.LexicographicTopologicalSort()
+	if !errors.As(err, &undirectedErr) {
+		t.Errorf("expected an UndirectedGraphError, got %v", err)
+	}
+}
+
+func TestNegativeEdgeCountFollowsMutations(t *testing.T) {
+	rng := rand.New(rand.NewSource(22))
+	for _, opts := range []GraphOptions{{}, {Undirected: true}, {Multi: true}, {Undirected: true, Multi: true}} {
+		g := MkGraphWithOptions[int, int](opts, nil, nil)
+		for step := 0; step < 2000; step++ {
+			u, v, wt := rng.Intn(5), rng.Intn(5), rng.Intn(7)-3
+			switch rng.Intn(6) {
+			case 0, 1:
+				g.AddEdge(Edge[int, int]{Frm: u, To: v, Wt: wt})
+			case 2:
+				g.UpdateWeight(u, v, wt)
+			case 3:
+				g.RemoveEdge(u, v)
+			case 4:
+				if rng.Intn(4) == 0 {
+					g.RemoveNode(u)
+				}
+			case 5:
+				edges := g.Edges()
+				if g.multi && len(edges) > 0 {
+					edge := edges[rng.Intn(len(edges))]
+					if rng.Intn(2) == 0 {
+						g.RemoveEdgeById(edge.Id)
+					} else {
+						g.UpdateEdgeWeight(edge.Id, wt)
+					}
+				}
+			}
+			negative := false
+			for _, edge := range g.Edges() {
+				negative = negative || edge.Wt < 0
+			}
+			if negative != (g.CheckWeightsNonNegative() != nil) {
+				t.Fatalf("%+v step %d: expected negative=%v, got %v for %v", opts, step, negative, g.CheckWeightsNonNegative(), g.Edges())
+			}
+			transposed := g.Transpose()
+			if negative != (transposed.CheckWeightsNonNegative() != nil) {
+				t.Fatalf("%+v step %d: expected the transpose to share the count", opts, step)
+			}
+		}
+	}
+}
+
+func TestInNeighborsAndDegrees(t *testing.T) {
+	g := MkGraph([]StringEdge{
+		{Frm: "a", To: "b", Wt: 1},
+		{Frm: "a", To: "c", Wt: 2},
+		{Frm: "c", To: "b", Wt: 3},
+		{Frm: "b", To: "b", Wt: 4},
+	}, []Node{"d"})
+	in := g.InNeighbors("b")
+	sortEdges(in)
+	expected := []StringEdge{{Frm: "a", To: "b", Wt: 1}, {Frm: "b", To: "b", Wt: 4}, {Frm: "c", To: "b", Wt: 3}}
+	if len(in) != len(expected) {
+		t.Fatalf("expected %v into b, got %v", expected, in)
+	}
+	for i := range in {
+		if in[i] != expected[i] {
+			t.Errorf("expected %v into b, got %v", expected, in)
+		}
+	}
+	parents := g.ImmediateParents("b")
+	if len(parents) != 3 || !parents["a"] || !parents["b"] || !parents["c"] {
+		t.Errorf("expected a, b and c as parents of b, got %v", parents)
+	}
+	for _, tc := range []struct {
+		node    Node
+		in, out int
+	}{{"a", 0, 2}, {"b", 3, 1}, {"c", 1, 1}, {"d", 0, 0}, {"missing", 0, 0}} {
+		if g.InDegree(tc.node) != tc.in || g.OutDegree(tc.node) != tc.out {
+			t.Errorf("%s: expected in and out degree %d %d, got %d %d", tc.node, tc.in, tc.out, g.InDegree(tc.node), g.OutDegree(tc.node))
+		}
+	}
+	if len(g.InNeighbors("d")) != 0 || len(g.InNeighbors("missing")) != 0 {
+		t.Error("expected no edges into d or an unknown node")
+	}
+}
+
+func TestTransposeWritesThrough(t *testing.T) {
+	g := MkGraph([]StringEdge{{Frm: "a", To: "b", Wt: 1}, {Frm: "c", To: "b", Wt: 3}}, nil)
+	tr := g.Transpose()
+	if tr.OutDegree("b") != 2 || tr.InDegree("a") != 1 || !tr.CanReach("b", "c") || tr.CanReach("a", "b") {
+		t.Errorf("expected every edge reversed, got %v", tr.Edges())
+	}
+	tr.AddEdge(StringEdge{Frm: "d", To: "a", Wt: 5})
+	err := tr.RemoveEdge("b", "c")
+	if err == nil {
+		err = tr.UpdateWeight("b", "a", 7)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	edges := g.Edges()
+	sortEdges(edges)
+	if len(edges) != 2 || edges[0] != (StringEdge{Frm: "a", To: "b", Wt: 7}) || edges[1] != (StringEdge{Frm: "a", To: "d", Wt: 5}) {
+		t.Errorf("expected writes through the view to show up reversed, got %v", edges)
+	}
+	back := tr.Transpose()
+	if back.OutDegree("a") != 2 || !back.CanReach("a", "d") {
+		t.Errorf("expected the transpose of the transpose to be g, got %v", back.Edges())
+	}
+
+	undirected := MkUndirectedGraph([]StringEdge{{Frm: "a", To: "b", Wt: 1}}, nil)
+	tr = undirected.Transpose()
+	if !tr.CanReach("a", "b") || !tr.CanReach("b", "a") || len(tr.Edges()) != 1 {
+		t.Errorf("expected an undirected graph to be its own transpose, got %v", tr.Edges())
+	}
+}
+
+func TestTransposeOfMultiGraphKeepsIds(t *testing.T) {
+	g := MkMultiGraph[Node, Weight](nil, nil)
+	first := g.AddLabeledEdge(StringEdge{Frm: "a", To: "b", Wt: 1}, "first")
+	g.AddEdge(StringEdge{Frm: "a", To: "b", Wt: 2})
+	tr := g.Transpose()
+	edge, ok := tr.EdgeById(first)
+	if !ok || edge.Frm != "b" || edge.To != "a" || tr.Labels(first)[0] != "first" {
+		t.Errorf("expected edge %d reversed with its labels, got %v %v", first, edge, tr.Labels(first))
+	}
+
+	added := tr.AddLabeledEdge(StringEdge{Frm: "c", To: "a", Wt: 7}, "added")
+	edge, ok = g.EdgeById(added)
+	if !ok || edge.Frm != "a" || edge.To != "c" || g.Labels(added)[0] != "added" {
+		t.Errorf("expected the edge added through the view as a -> c, got %v %v", edge, ok)
+	}
+	err := tr.UpdateEdgeWeight(first, 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edge, _ = g.EdgeById(first)
+	if edge.Frm != "a" || edge.Wt != 9 || g.adjacencyMatrix["a"]["b"] != 2 {
+		t.Errorf("expected a -> b to weigh 9 with the cheaper parallel edge kept, got %v", edge)
+	}
+	err = tr.RemoveEdgeById(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.OutDegree("a") != 2 || g.InDegree("b") != 1 || tr.OutDegree("b") != 1 {
+		t.Errorf("expected one edge a -> b left, got %v", g.Edges())
+	}
+	checkConsistent(t, &g)
+	checkConsistent(t, &tr)
+}
+
+func TestPathWithoutEdgesHasItsStart(t *testing.T) {
+	g := MkGraph([]StringEdge{{Frm: "a", To: "b", Wt: 1}, {Frm: "b", To: "a", Wt: 1}}, nil)
+	aStar, err := g.AStar("a", "a", func(Node) Weight { return 0 })
+	if err != nil {
+		t.Fatal(err)
+	}
+	yen, err := g.KShortestPaths("a", "a", 1)
+	if err != nil || len(yen) != 1 {
+		t.Fatalf("expected one path from a to itself, got %v %v", yen, err)
+	}
+	signedGraph := MkGraph([]SignedEdge{{Frm: "a", To: "b", Wt: -1}}, nil)
+	bellmanFord, err := signedGraph.BellmanFord("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, path := range map[string]*StringPath{
+		"ShortestPath":             g.ShortestPath("a", "a"),
+		"FrozenGraph.ShortestPath": g.Freeze().ShortestPath("a", "a"),
+		"AStar":                    aStar,
+		"KShortestPaths":           &yen[0],
+		"ShortestTimesFrom":        g.ShortestTimesFrom("a").PathTo("a"),
+	} {
+		if path == nil || len(path.Edges) != 0 || len(path.Nodes()) != 1 || path.Nodes()[0] != "a" {
+			t.Errorf("%s: expected the path from a to itself to be just a, got %v", name, path)
+		}
+	}
+	signed := bellmanFord.PathTo("a")
+	if signed == nil || len(signed.Nodes()) != 1 || signed.Nodes()[0] != "a" {
+		t.Errorf("BellmanFord: expected the path from a to itself to be just a, got %v", signed)
+	}
+}
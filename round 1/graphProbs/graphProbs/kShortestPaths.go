@@ -0,0 +1,115 @@
+package graphProbs
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// pathKey is the node sequence of p, followed by the edge ids when p runs
+// over a multigraph so that paths along different parallel edges differ.
+func pathKey[N Ordered, W Number](p *Path[N, W]) string {
+	key := strings.Join(formatNodes(p.Nodes()), "\x00")
+	for _, edge := range p.Edges {
+		if edge.Id != 0 {
+			key += "\x00" + strconv.FormatUint(edge.Id, 10)
+		}
+	}
+	return key
+}
+
+// lessPath orders paths by weight, then by their node sequence, then by the
+// ids of their edges.
+func lessPath[N Ordered, W Number](a *Path[N, W], b *Path[N, W]) bool {
+	if a.Wt != b.Wt {
+		return a.Wt < b.Wt
+	}
+	aNodes, bNodes := a.Nodes(), b.Nodes()
+	for i := 0; i < len(aNodes) && i < len(bNodes); i++ {
+		if aNodes[i] != bNodes[i] {
+			return aNodes[i] < bNodes[i]
+		}
+	}
+	if len(aNodes) != len(bNodes) {
+		return len(aNodes) < len(bNodes)
+	}
+	for i := range a.Edges {
+		if a.Edges[i].Id != b.Edges[i].Id {
+			return a.Edges[i].Id < b.Edges[i].Id
+		}
+	}
+	return false
+}
+
+func samePrefix[N Ordered, W Number](a *Path[N, W], b *Path[N, W], n int) bool {
+	if len(a.Edges) < n || len(b.Edges) < n {
+		return false
+	}
+	for i := 0; i < n; i++ {
+		if a.Edges[i] != b.Edges[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// KShortestPaths returns up to k loopless paths from start to end in ascending
+// order of weight using Yen's algorithm. Paths of equal weight are ordered by
+// their node sequence, but when more than k paths tie for the last places the
+// ones returned are not necessarily the first in that order.
+func (g *Graph[N, W]) KShortestPaths(start N, end N, k int) ([]Path[N, W], error) {
+	if k <= 0 {
+		return nil, nil
+	}
+	first, err := g.CheckedShortestPath(start, end)
+	if first == nil {
+		return nil, err
+	}
+	paths := []Path[N, W]{*first}
+	seen := map[string]bool{pathKey(first): true}
+	candidates := []Path[N, W]{}
+	for len(paths) < k {
+		prev := &paths[len(paths)-1]
+		prevNodes := prev.Nodes()
+		rootWt := W(0)
+		for i := 0; i < len(prev.Edges); i++ {
+			spurNode := prevNodes[i]
+			blockedEdges := map[Edge[N, W]]bool{}
+			for j := range paths {
+				if samePrefix(&paths[j], prev, i) && len(paths[j].Edges) > i {
+					blockedEdges[paths[j].Edges[i]] = true
+				}
+			}
+			blockedNodes := map[N]bool{}
+			for _, node := range prevNodes[:i] {
+				blockedNodes[node] = true
+			}
+
+			dists, preds := g.dijkstra(spurNode, &end, blockedNodes, blockedEdges)
+			spurWt, ok := dists[end]
+			if ok {
+				spurPath := tracePath(preds, spurNode, end, spurWt)
+				edges := make([]Edge[N, W], 0, i+len(spurPath.Edges))
+				edges = append(edges, prev.Edges[:i]...)
+				edges = append(edges, spurPath.Edges...)
+				candidate := Path[N, W]{Start: start, Edges: edges, Wt: rootWt + spurWt}
+				key := pathKey(&candidate)
+				if !seen[key] {
+					seen[key] = true
+					candidates = append(candidates, candidate)
+				}
+			}
+			rootWt += prev.Edges[i].Wt
+		}
+		if len(candidates) == 0 {
+			break
+		}
+		sort.SliceStable(candidates, func(i, j int) bool { return lessPath(&candidates[i], &candidates[j]) })
+		paths = append(paths, candidates[0])
+		candidates = candidates[1:]
+	}
+	// a path can only turn up as a candidate once a path of the same weight it
+	// deviates from has been taken, so ties are put in order at the end
+	sort.SliceStable(paths, func(i, j int) bool { return lessPath(&paths[i], &paths[j]) })
+	return paths, nil
+}
@@ -0,0 +1,119 @@
+package graphProbs
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func formatPath(p *StringPath) string {
+	return strings.Join(p.Nodes(), " ")
+}
+
+func TestKShortestPathsOrdersTiesByNodes(t *testing.T) {
+	g := MkGraph([]StringEdge{
+		{Frm: "s", To: "a", Wt: 3},
+		{Frm: "s", To: "b", Wt: 2},
+		{Frm: "a", To: "t", Wt: 2},
+		{Frm: "b", To: "t", Wt: 3},
+		{Frm: "a", To: "b", Wt: 1},
+	}, nil)
+	paths, err := g.KShortestPaths("s", "t", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"s a t", "s b t", "s a b t"}
+	if len(paths) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, paths)
+	}
+	for i := range paths {
+		if formatPath(&paths[i]) != expected[i] {
+			t.Errorf("path %d: expected %s, got %s", i, expected[i], formatPath(&paths[i]))
+		}
+	}
+}
+
+// simplePaths enumerates every loopless path from start to end, in the order
+// KShortestPaths promises.
+func simplePaths(g *StringGraph, start Node, end Node) []StringPath {
+	paths := []StringPath{}
+	onPath := map[Node]bool{start: true}
+	edges := []StringEdge{}
+	var extend func(node Node, wt Weight)
+	extend = func(node Node, wt Weight) {
+		if node == end {
+			paths = append(paths, StringPath{Start: start, Edges: append([]StringEdge{}, edges...), Wt: wt})
+			return
+		}
+		for _, edge := range g.Neighbors(node) {
+			if onPath[edge.To] {
+				continue
+			}
+			onPath[edge.To] = true
+			edges = append(edges, edge)
+			extend(edge.To, wt+edge.Wt)
+			edges = edges[:len(edges)-1]
+			onPath[edge.To] = false
+		}
+	}
+	extend(start, 0)
+	sort.Slice(paths, func(i, j int) bool { return lessPath(&paths[i], &paths[j]) })
+	return paths
+}
+
+func TestKShortestPathsMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(6))
+	for round := 0; round < 60; round++ {
+		g := randomGraph(rng, 6, 12, 4)
+		switch round % 3 {
+		case 1:
+			g = MkUndirectedGraph(g.Edges(), g.Nodes())
+		case 2:
+			edges := g.Edges()
+			for _, edge := range g.Edges()[:len(edges)/2] {
+				edge.Wt = Weight(rng.Intn(5))
+				edges = append(edges, edge)
+			}
+			g = MkMultiGraph(edges, g.Nodes())
+		}
+		nodes := g.sortedNodes()
+		for _, start := range nodes {
+			for _, end := range nodes {
+				if start == end {
+					continue
+				}
+				expected := simplePaths(&g, start, end)
+				all, err := g.KShortestPaths(start, end, len(expected)+1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(all) != len(expected) {
+					t.Fatalf("round %d, %s -> %s: expected all %d simple paths, got %d", round, start, end, len(expected), len(all))
+				}
+				for i := range all {
+					if pathKey(&all[i]) != pathKey(&expected[i]) || all[i].Wt != expected[i].Wt {
+						t.Errorf("round %d, %s -> %s: path %d: expected %s (%d), got %s (%d)", round, start, end, i, formatPath(&expected[i]), expected[i].Wt, formatPath(&all[i]), all[i].Wt)
+					}
+				}
+
+				k := 1 + rng.Intn(3)
+				some, err := g.KShortestPaths(start, end, k)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(expected) < k {
+					k = len(expected)
+				}
+				if len(some) != k {
+					t.Fatalf("round %d, %s -> %s: expected %d paths, got %d", round, start, end, k, len(some))
+				}
+				for i := range some {
+					if some[i].Wt != expected[i].Wt {
+						t.Errorf("round %d, %s -> %s: path %d: expected weight %d, got %d", round, start, end, i, expected[i].Wt, some[i].Wt)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package graphProbs
+
+import (
+	"fmt"
+	"graphProbs/dinic"
+	"sort"
+)
+
+type SameEndpointsError[N Ordered] struct {
+	Node N
+}
+
+func (e *SameEndpointsError[N]) Error() string {
+	return fmt.Sprintf("no cut exists between %v and itself", e.Node)
+}
+
+// InvalidCapacityError is returned by the algorithms reading weights as
+// capacities when a weight is not a whole number that fits in a uint64.
+type InvalidCapacityError[N Ordered, W Number] struct {
+	Edge Edge[N, W]
+}
+
+func (e *InvalidCapacityError[N, W]) Error() string {
+	return fmt.Sprintf("edge %v -> %v has weight %v, capacities must be whole numbers", e.Edge.Frm, e.Edge.To, e.Edge.Wt)
+}
+
+// CheckWeightsAreCapacities returns a *NegativeWeightError, or an
+// *InvalidCapacityError for the edge that sorts first among those whose weight
+// does not convert to a uint64 unchanged, such as fractional ones.
+func (g *Graph[N, W]) CheckWeightsAreCapacities() error {
+	err := g.CheckWeightsNonNegative()
+	if err != nil {
+		return err
+	}
+	if W(1)/2 == 0 {
+		// Integer weights that are not negative always fit.
+		return nil
+	}
+	invalid := []Edge[N, W]{}
+	for _, edge := range g.Edges() {
+		if W(uint64(edge.Wt)) != edge.Wt {
+			invalid = append(invalid, edge)
+		}
+	}
+	if len(invalid) == 0 {
+		return nil
+	}
+	sortEdges(invalid)
+	return &InvalidCapacityError[N, W]{Edge: invalid[0]}
+}
+
+// MinimumEdgeCut returns a smallest set of edges whose removal leaves dst
+// unreachable from src. Edges of an undirected graph are reported oriented
+// from the side of src to the side of dst.
+func (g *Graph[N, W]) MinimumEdgeCut(src N, dst N) ([]Edge[N, W], error) {
+	return g.edgeCut(src, dst, func(Edge[N, W]) uint64 { return 1 })
+}
+
+// MinimumWeightedEdgeCut treats the weight of an edge as the cost of removing
+// it and returns a set of edges of least total cost. Weights must be whole
+// numbers, see CheckWeightsAreCapacities.
+func (g *Graph[N, W]) MinimumWeightedEdgeCut(src N, dst N) ([]Edge[N, W], error) {
+	err := g.CheckWeightsAreCapacities()
+	if err != nil {
+		return nil, err
+	}
+	return g.edgeCut(src, dst, func(e Edge[N, W]) uint64 { return uint64(e.Wt) })
+}
+
+func (g *Graph[N, W]) edgeCut(src N, dst N, capacity func(Edge[N, W]) uint64) ([]Edge[N, W], error) {
+	for _, node := range []N{src, dst} {
+		if !g.IsValidNode(node) {
+			return nil, &NodeNotFoundError[N]{Node: node}
+		}
+	}
+	if src == dst {
+		return nil, &SameEndpointsError[N]{Node: src}
+	}
+
+	nodes := g.sortedNodes()
+	indices := nodeIndices(nodes)
+	network := dinic.New(len(nodes))
+	for _, edge := range g.Arcs() {
+		network.AddArc(indices[edge.Frm], indices[edge.To], capacity(edge))
+	}
+
+	s := indices[src]
+	network.MaxFlow(s, indices[dst])
+	reached := network.SourceSide(s)
+	cut := []Edge[N, W]{}
+	for _, edge := range g.Arcs() {
+		if reached[indices[edge.Frm]] && !reached[indices[edge.To]] {
+			cut = append(cut, edge)
+		}
+	}
+	sortEdges(cut)
+	return cut, nil
+}
+
+func sortEdges[N Ordered, W Number](edges []Edge[N, W]) {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Frm != edges[j].Frm {
+			return edges[i].Frm < edges[j].Frm
+		}
+		if edges[i].To != edges[j].To {
+			return edges[i].To < edges[j].To
+		}
+		return edges[i].Id < edges[j].Id
+	})
+}
@@ -1,16 +1,37 @@
 package graphProbs
 
-func (g *Graph) NeighborsToBlockToEnsureUnreachability(follower Node, following Node) <-chan Node {
-	retCh := make(chan Node)
+import "context"
+
+// WalkNeighborsToBlockToEnsureUnreachability calls visit, on the calling
+// goroutine, with every immediate parent of following that follower can reach
+// without going through following. It stops early once visit returns false.
+func (g *Graph[N, W]) WalkNeighborsToBlockToEnsureUnreachability(follower N, following N, visit func(N) bool) {
+	immediateParents := g.ImmediateParents(following)
+	g.WalkReachableNodes(follower, map[N]bool{following: true}, func(reachableNode N) bool {
+		_, ok := immediateParents[reachableNode]
+		if ok {
+			return visit(reachableNode)
+		}
+		return true
+	})
+}
+
+func (g *Graph[N, W]) NeighborsToBlockToEnsureUnreachabilityContext(ctx context.Context, follower N, following N) <-chan N {
+	retCh := make(chan N)
 	go func() {
-		immediateParents := g.ImmediateParents(following)
-		for reachableNode := range g.ReachableNodes(follower, map[Node]bool{following: true}) {
-			_, ok := immediateParents[reachableNode]
-			if ok {
-				retCh <- reachableNode
+		defer close(retCh)
+		g.WalkNeighborsToBlockToEnsureUnreachability(follower, following, func(node N) bool {
+			select {
+			case retCh <- node:
+				return true
+			case <-ctx.Done():
+				return false
 			}
-		}
-		close(retCh)
+		})
 	}()
 	return retCh
 }
+
+func (g *Graph[N, W]) NeighborsToBlockToEnsureUnreachability(follower N, following N) <-chan N {
+	return g.NeighborsToBlockToEnsureUnreachabilityContext(context.Background(), follower, following)
+}
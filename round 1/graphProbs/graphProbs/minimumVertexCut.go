@@ -0,0 +1,63 @@
+package graphProbs
+
+import (
+	"fmt"
+	"graphProbs/dinic"
+)
+
+type DirectEdgeError[N Ordered] struct {
+	Frm N
+	To  N
+}
+
+func (e *DirectEdgeError[N]) Error() string {
+	return fmt.Sprintf("no vertex cut exists, %v directly follows %v", e.Frm, e.To)
+}
+
+// MinimumVertexCut returns a smallest set of nodes, other than follower and
+// following, whose removal leaves following unreachable from follower. Every
+// node v is split into v_in -> v_out with capacity 1 and every edge u -> v
+// becomes u_out -> v_in with infinite capacity, so a minimum cut in the split
+// network only ever crosses the unit node arcs.
+func (g *Graph[N, W]) MinimumVertexCut(follower N, following N) ([]N, error) {
+	for _, node := range []N{follower, following} {
+		if !g.IsValidNode(node) {
+			return nil, &NodeNotFoundError[N]{Node: node}
+		}
+	}
+	if follower == following {
+		return nil, &SameEndpointsError[N]{Node: follower}
+	}
+	_, ok := g.adjacencyMatrix[follower][following]
+	if ok {
+		return nil, &DirectEdgeError[N]{Frm: follower, To: following}
+	}
+
+	nodes := g.sortedNodes()
+	indices := nodeIndices(nodes)
+	in := func(i int) int { return 2 * i }
+	out := func(i int) int { return 2*i + 1 }
+	network := dinic.New(2 * len(nodes))
+	for i, node := range nodes {
+		capacity := uint64(1)
+		if node == follower || node == following {
+			capacity = dinic.InfiniteCapacity
+		}
+		network.AddArc(in(i), out(i), capacity)
+	}
+	for _, edge := range g.Arcs() {
+		network.AddArc(out(indices[edge.Frm]), in(indices[edge.To]), dinic.InfiniteCapacity)
+	}
+
+	s := out(indices[follower])
+	t := in(indices[following])
+	network.MaxFlow(s, t)
+	reached := network.SourceSide(s)
+	cut := []N{}
+	for i, node := range nodes {
+		if reached[in(i)] && !reached[out(i)] {
+			cut = append(cut, node)
+		}
+	}
+	return cut, nil
+}
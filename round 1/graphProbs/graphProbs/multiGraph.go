@@ -0,0 +1,261 @@
+package graphProbs
+
+import "fmt"
+
+type EdgeId = uint64
+
+func MkMultiGraph[N Ordered, W Number](edges []Edge[N, W], nodes []N) Graph[N, W] {
+	return MkGraphWithOptions(GraphOptions{Multi: true}, edges, nodes)
+}
+
+func (g *Graph[N, W]) IsMulti() bool {
+	return g.multi
+}
+
+type ParallelEdgesError[N Ordered] struct {
+	Frm N
+	To  N
+	Ids []EdgeId
+}
+
+func (e *ParallelEdgesError[N]) Error() string {
+	return fmt.Sprintf("%v -> %v is joined by %d parallel edges %v, refer to one by its id", e.Frm, e.To, len(e.Ids), e.Ids)
+}
+
+type EdgeIdNotFoundError struct {
+	Id EdgeId
+}
+
+func (e *EdgeIdNotFoundError) Error() string {
+	return fmt.Sprintf("edge %d is not part of the graph", e.Id)
+}
+
+func reversedEdge[N Ordered, W Number](e Edge[N, W]) Edge[N, W] {
+	e.Frm, e.To = e.To, e.Frm
+	return e
+}
+
+func appendParallel[N Ordered, W Number](m map[N]map[N][]Edge[N, W], e Edge[N, W]) {
+	assocs := m[e.Frm]
+	if assocs == nil {
+		assocs = map[N][]Edge[N, W]{}
+		m[e.Frm] = assocs
+	}
+	assocs[e.To] = append(assocs[e.To], e)
+}
+
+func flattenParallel[N Ordered, W Number](assocs map[N][]Edge[N, W], reverse bool) []Edge[N, W] {
+	edges := make([]Edge[N, W], 0, len(assocs))
+	for _, parallel := range assocs {
+		for _, edge := range parallel {
+			if reverse {
+				edge = reversedEdge(edge)
+			}
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+func countParallel[N Ordered, W Number](assocs map[N][]Edge[N, W]) int {
+	count := 0
+	for _, parallel := range assocs {
+		count += len(parallel)
+	}
+	return count
+}
+
+// reserveEdgeIds moves the next free id past every id set in edges, so that
+// edges added without one never take an id a later edge asks for.
+func (g *Graph[N, W]) reserveEdgeIds(edges []Edge[N, W]) {
+	for _, edge := range edges {
+		if edge.Id > *g.nextEdgeId {
+			*g.nextEdgeId = edge.Id
+		}
+	}
+}
+
+// addParallelEdge keeps a caller supplied Id, replacing the edge that had it
+// along with its labels, or assigns the next free one when it is 0, and stores
+// e without replacing other edges of the pair.
+func (g *Graph[N, W]) addParallelEdge(e Edge[N, W]) EdgeId {
+	old, ok := g.EdgeById(e.Id)
+	if ok {
+		g.countNegative(old.Wt, -1)
+		delete(g.edgesById, e.Id)
+		delete(g.edgeLabels, e.Id)
+		g.rewriteParallel(old.Frm, old.To, e.Id, func(*Edge[N, W]) bool { return false })
+	}
+	g.countNegative(e.Wt, 1)
+	if e.Id == 0 {
+		*g.nextEdgeId += 1
+		e.Id = *g.nextEdgeId
+	} else if e.Id > *g.nextEdgeId {
+		*g.nextEdgeId = e.Id
+	}
+	original := e
+	if g.transposed {
+		original = reversedEdge(e)
+	}
+	g.edgesById[e.Id] = original
+
+	appendParallel(g.parallelEdges, e)
+	appendParallel(g.reverseParallelEdges, reversedEdge(e))
+	if g.undirected && e.Frm != e.To {
+		appendParallel(g.parallelEdges, reversedEdge(e))
+		appendParallel(g.reverseParallelEdges, e)
+	}
+	g.refreshCheapest(e.Frm, e.To)
+	return e.Id
+}
+
+// AddLabeledEdge adds e like AddEdge, attaches labels to it and returns the id
+// it was stored under. Only multigraphs keep ids and labels, other graphs
+// return 0.
+func (g *Graph[N, W]) AddLabeledEdge(e Edge[N, W], labels ...string) EdgeId {
+	id := g.addEdge(e)
+	if g.multi && len(labels) > 0 {
+		g.edgeLabels[id] = labels
+	}
+	return id
+}
+
+// Labels returns the labels of the edge with the given id, the caller must not
+// modify them.
+func (g *Graph[N, W]) Labels(id EdgeId) []string {
+	if !g.multi {
+		return nil
+	}
+	return g.edgeLabels[id]
+}
+
+func (g *Graph[N, W]) SetLabels(id EdgeId, labels ...string) error {
+	_, ok := g.EdgeById(id)
+	if !ok {
+		return &EdgeIdNotFoundError{Id: id}
+	}
+	if len(labels) == 0 {
+		delete(g.edgeLabels, id)
+		return nil
+	}
+	g.edgeLabels[id] = labels
+	return nil
+}
+
+// refreshCheapest stores the smallest weight among the parallel edges of a
+// pair in the adjacency matrices, or drops the pair once it has no edges.
+func (g *Graph[N, W]) refreshCheapest(frm N, to N) {
+	pairs := [][2]N{{frm, to}}
+	if g.undirected && frm != to {
+		pairs = append(pairs, [2]N{to, frm})
+	}
+	for _, pair := range pairs {
+		u, v := pair[0], pair[1]
+		parallel := g.parallelEdges[u][v]
+		if len(parallel) == 0 {
+			delete(g.parallelEdges[u], v)
+			delete(g.reverseParallelEdges[v], u)
+			delete(g.adjacencyMatrix[u], v)
+			delete(g.reverseAdjacencyMatrix[v], u)
+			continue
+		}
+		cheapest := parallel[0].Wt
+		for _, edge := range parallel[1:] {
+			if edge.Wt < cheapest {
+				cheapest = edge.Wt
+			}
+		}
+		addAssociation(g.adjacencyMatrix, u, v, cheapest)
+		addAssociation(g.reverseAdjacencyMatrix, v, u, cheapest)
+	}
+}
+
+func (g *Graph[N, W]) EdgeById(id EdgeId) (Edge[N, W], bool) {
+	if !g.multi {
+		return Edge[N, W]{}, false
+	}
+	edge, ok := g.edgesById[id]
+	if ok && g.transposed {
+		edge = reversedEdge(edge)
+	}
+	return edge, ok
+}
+
+// rewriteParallel replaces the edge with the given id between frm and to,
+// in every matrix that holds it, by update(edge), or drops it when update
+// returns false.
+func (g *Graph[N, W]) rewriteParallel(frm N, to N, id EdgeId, update func(*Edge[N, W]) bool) {
+	rewrite := func(m map[N]map[N][]Edge[N, W], u N, v N, reverse bool) {
+		parallel := m[u][v]
+		kept := parallel[:0]
+		for _, edge := range parallel {
+			if edge.Id == id {
+				if reverse {
+					edge = reversedEdge(edge)
+				}
+				if !update(&edge) {
+					continue
+				}
+				if reverse {
+					edge = reversedEdge(edge)
+				}
+			}
+			kept = append(kept, edge)
+		}
+		if m[u] != nil {
+			m[u][v] = kept
+		}
+	}
+	rewrite(g.parallelEdges, frm, to, false)
+	rewrite(g.reverseParallelEdges, to, frm, true)
+	if g.undirected && frm != to {
+		rewrite(g.parallelEdges, to, frm, true)
+		rewrite(g.reverseParallelEdges, frm, to, false)
+	}
+	g.refreshCheapest(frm, to)
+}
+
+func (g *Graph[N, W]) RemoveEdgeById(id EdgeId) error {
+	edge, ok := g.EdgeById(id)
+	if !ok {
+		return &EdgeIdNotFoundError{Id: id}
+	}
+	*g.version += 1
+	g.countNegative(edge.Wt, -1)
+	delete(g.edgesById, id)
+	delete(g.edgeLabels, id)
+	g.rewriteParallel(edge.Frm, edge.To, id, func(*Edge[N, W]) bool { return false })
+	return nil
+}
+
+func (g *Graph[N, W]) UpdateEdgeWeight(id EdgeId, wt W) error {
+	edge, ok := g.EdgeById(id)
+	if !ok {
+		return &EdgeIdNotFoundError{Id: id}
+	}
+	*g.version += 1
+	original := g.edgesById[id]
+	g.countNegative(original.Wt, -1)
+	g.countNegative(wt, 1)
+	original.Wt = wt
+	g.edgesById[id] = original
+	g.rewriteParallel(edge.Frm, edge.To, id, func(e *Edge[N, W]) bool {
+		e.Wt = wt
+		return true
+	})
+	return nil
+}
+
+// removeParallelPair drops every edge between frm and to.
+func (g *Graph[N, W]) removeParallelPair(frm N, to N) {
+	for _, edge := range g.parallelEdges[frm][to] {
+		g.countNegative(edge.Wt, -1)
+		delete(g.edgesById, edge.Id)
+		delete(g.edgeLabels, edge.Id)
+	}
+	g.parallelEdges[frm][to] = nil
+	if g.undirected && frm != to {
+		g.parallelEdges[to][frm] = nil
+	}
+	g.refreshCheapest(frm, to)
+}
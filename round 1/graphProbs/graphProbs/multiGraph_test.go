@@ -0,0 +1,137 @@
+package graphProbs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestParallelEdges(t *testing.T) {
+	for _, undirected := range []bool{false, true} {
+		g := MkGraphWithOptions(GraphOptions{Undirected: undirected, Multi: true}, []StringEdge{{Frm: "b", To: "c", Wt: 1}}, nil)
+		g.AddLabeledEdge(StringEdge{Frm: "a", To: "b", Wt: 5}, "dm")
+		mention := g.AddLabeledEdge(StringEdge{Frm: "a", To: "b", Wt: 2}, "mention")
+		if len(g.Neighbors("a")) != 2 || g.OutDegree("a") != 2 {
+			t.Fatalf("undirected=%v: expected both parallel edges out of a, got %v", undirected, g.Neighbors("a"))
+		}
+		if len(g.Edges()) != 3 {
+			t.Errorf("undirected=%v: expected 3 edges, got %v", undirected, g.Edges())
+		}
+		wt := g.ShortestTime("a", "c")
+		if wt == nil || *wt != 3 {
+			t.Errorf("undirected=%v: expected shortest time 3 over the cheaper edge, got %v", undirected, wt)
+		}
+		cut, err := g.MinimumEdgeCut("a", "c")
+		if err != nil || len(cut) != 1 || cut[0].Frm != "b" {
+			t.Errorf("undirected=%v: expected the single edge b -> c as cut, got %v %v", undirected, cut, err)
+		}
+		cut, err = g.MinimumEdgeCut("a", "b")
+		if err != nil || len(cut) != 2 {
+			t.Errorf("undirected=%v: expected both parallel edges in the cut, got %v %v", undirected, cut, err)
+		}
+		bridges := g.Bridges()
+		if len(bridges) != 1 || bridges[0].Frm != "b" || bridges[0].To != "c" {
+			t.Errorf("undirected=%v: expected only b -> c as bridge, got %v", undirected, bridges)
+		}
+
+		var parallelErr *ParallelEdgesError[Node]
+		if !errors.As(g.UpdateWeight("a", "b", 1), &parallelErr) {
+			t.Errorf("undirected=%v: expected a ParallelEdgesError", undirected)
+		}
+		path := g.ShortestPath("a", "b")
+		cheap := path.Edges[0]
+		if cheap.Id != mention || g.Labels(cheap.Id)[0] != "mention" {
+			t.Errorf("undirected=%v: expected the mention edge, got %v", undirected, cheap)
+		}
+		err = g.RemoveEdgeById(cheap.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wt = g.ShortestTime("a", "c")
+		if wt == nil || *wt != 6 {
+			t.Errorf("undirected=%v: expected shortest time 6 once the cheaper edge is gone, got %v", undirected, wt)
+		}
+		err = g.UpdateWeight("a", "b", 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wt = g.ShortestTime("c", "a")
+		if undirected != (wt != nil) {
+			t.Errorf("undirected=%v: unexpected shortest time from c to a %v", undirected, wt)
+		}
+		err = g.RemoveEdge("a", "b")
+		if err != nil || g.CanReach("a", "b") || len(g.Edges()) != 1 {
+			t.Errorf("undirected=%v: expected every edge between a and b gone, got %v %v", undirected, g.Edges(), err)
+		}
+	}
+}
+
+func TestMultiGraphRoundTrip(t *testing.T) {
+	g := MkMultiGraph[Node, Weight](nil, nil)
+	g.AddLabeledEdge(StringEdge{Frm: "a", To: "b", Wt: 5}, "dm", "<re: a & b>")
+	g.AddLabeledEdge(StringEdge{Frm: "a", To: "b", Wt: 2}, "mention")
+	g.AddEdge(StringEdge{Frm: "b", To: "c", Wt: 1, Id: 7})
+	for _, format := range []struct {
+		name  string
+		write func(*bytes.Buffer) error
+		read  func(*bytes.Buffer) (StringGraph, error)
+	}{
+		{"json", func(buf *bytes.Buffer) error { return g.WriteJSON(buf) }, func(buf *bytes.Buffer) (StringGraph, error) { return ReadJSON(buf) }},
+		{"graphml", func(buf *bytes.Buffer) error { return g.WriteGraphML(buf) }, func(buf *bytes.Buffer) (StringGraph, error) { return ReadGraphML(buf) }},
+		{"dot", func(buf *bytes.Buffer) error { return g.WriteDOT(buf, DOTOptions[Node, Weight]{}) }, func(buf *bytes.Buffer) (StringGraph, error) { return ReadDOT(buf) }},
+	} {
+		var buf bytes.Buffer
+		err := format.write(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		read, err := format.read(&buf)
+		if err != nil {
+			t.Fatalf("%s: %v", format.name, err)
+		}
+		expected, edges := g.Edges(), read.Edges()
+		sortEdges(expected)
+		sortEdges(edges)
+		if !read.IsMulti() || len(edges) != len(expected) {
+			t.Fatalf("%s: expected all 3 edges of a multigraph back, got %v", format.name, edges)
+		}
+		for i := range edges {
+			if edges[i] != expected[i] {
+				t.Errorf("%s: expected %v, got %v", format.name, expected[i], edges[i])
+			}
+		}
+		for i := range edges {
+			expectedLabels := g.Labels(expected[i].Id)
+			if format.name == "dot" {
+				expectedLabels = nil
+			}
+			if fmt.Sprint(read.Labels(edges[i].Id)) != fmt.Sprint(expectedLabels) {
+				t.Errorf("%s: expected the labels %v on %v, got %v", format.name, expectedLabels, edges[i], read.Labels(edges[i].Id))
+			}
+		}
+	}
+}
+
+func TestExplicitIdsTakePrecedence(t *testing.T) {
+	g, err := ReadJSON(bytes.NewBufferString(`{"multi": true, "nodes": ["a", "b"], "edges": [
+		{"from": "a", "to": "b", "weight": 1},
+		{"from": "a", "to": "b", "weight": 2, "id": 1}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	edge, ok := g.EdgeById(1)
+	if len(g.Edges()) != 2 || !ok || edge.Wt != 2 {
+		t.Errorf("expected the edge without id to be given a fresh one, got %v", g.Edges())
+	}
+
+	_, err = ReadJSON(bytes.NewBufferString(`{"multi": true, "nodes": ["a", "b"], "edges": [
+		{"from": "a", "to": "b", "weight": 1, "id": 3},
+		{"from": "b", "to": "a", "weight": 2, "id": 3}
+	]}`))
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Kind != "duplicate edge" || validationErr.Index != 1 {
+		t.Errorf("expected a duplicate edge error at index 1, got %v", err)
+	}
+}
@@ -0,0 +1,269 @@
+package graphProbs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// The problem format is line based:
+//
+//	<number of nodes>
+//	<node>            (one per line)
+//	<number of edges>
+//	<from> <to> [wt]  (one per line, wt only for weighted problems)
+//	<follower>
+//	<following>
+//
+// In batch mode the follower and following lines are replaced by any number of
+// "<follower> <following>" lines. Blank lines are ignored throughout. A later
+// edge between the same pair replaces an earlier one, unless the problem is
+// read as a multigraph.
+
+type Query struct {
+	Follower  Node
+	Following Node
+}
+
+type Problem struct {
+	Graph   StringGraph
+	Queries []Query
+}
+
+type ProblemOptions struct {
+	Weighted   bool
+	Batch      bool
+	Undirected bool
+	Multi      bool
+}
+
+type ParseError struct {
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Msg)
+}
+
+type field struct {
+	text   string
+	column int
+}
+
+type problemScanner struct {
+	scanner *bufio.Scanner
+	lineNo  int
+	// fields of the current line, nil at end of input.
+	fields []field
+}
+
+func splitFields(line string) []field {
+	fields := []field{}
+	start := -1
+	for i := 0; i <= len(line); i++ {
+		isSpace := i == len(line) || line[i] == ' ' || line[i] == '\t' || line[i] == '\r'
+		if !isSpace && start < 0 {
+			start = i
+		}
+		if isSpace && start >= 0 {
+			fields = append(fields, field{text: line[start:i], column: start + 1})
+			start = -1
+		}
+	}
+	return fields
+}
+
+// next advances to the next non blank line, returning false at end of input.
+func (s *problemScanner) next() (bool, error) {
+	for s.scanner.Scan() {
+		s.lineNo++
+		s.fields = splitFields(s.scanner.Text())
+		if len(s.fields) > 0 {
+			return true, nil
+		}
+	}
+	s.fields = nil
+	s.lineNo++
+	return false, s.scanner.Err()
+}
+
+func (s *problemScanner) errorf(column int, format string, args ...any) error {
+	return &ParseError{Line: s.lineNo, Column: column, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (s *problemScanner) expectLine(what string) error {
+	ok, err := s.next()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return s.errorf(1, "unexpected end of input, expected %s", what)
+	}
+	return nil
+}
+
+func (s *problemScanner) expectFields(n int, what string) error {
+	if len(s.fields) != n {
+		column := 1
+		if len(s.fields) > n {
+			column = s.fields[n].column
+		}
+		return s.errorf(column, "expected %s, found %d fields", what, len(s.fields))
+	}
+	return nil
+}
+
+func (s *problemScanner) readCount(what string) (int, error) {
+	err := s.expectLine(what)
+	if err != nil {
+		return 0, err
+	}
+	err = s.expectFields(1, what)
+	if err != nil {
+		return 0, err
+	}
+	count, err := strconv.ParseUint(s.fields[0].text, 10, 31)
+	if err != nil {
+		return 0, s.errorf(s.fields[0].column, "expected %s, found %q", what, s.fields[0].text)
+	}
+	return int(count), nil
+}
+
+// ParseProblem reads a problem from r one line at a time. Every error is a
+// *ParseError pointing at the offending line and column, except for errors
+// returned by r itself.
+func ParseProblem(r io.Reader, opts ProblemOptions) (*Problem, error) {
+	s := &problemScanner{scanner: bufio.NewScanner(r)}
+
+	numNodes, err := s.readCount("the number of nodes")
+	if err != nil {
+		return nil, err
+	}
+	g := MkGraphWithOptions[Node, Weight](GraphOptions{Undirected: opts.Undirected, Multi: opts.Multi}, nil, nil)
+	for i := 0; i < numNodes; i++ {
+		err = s.expectLine(fmt.Sprintf("node %d of %d", i+1, numNodes))
+		if err != nil {
+			return nil, err
+		}
+		err = s.expectFields(1, "a single node name")
+		if err != nil {
+			return nil, err
+		}
+		node := s.fields[0].text
+		if g.IsValidNode(node) {
+			return nil, s.errorf(s.fields[0].column, "node %s is declared more than once", node)
+		}
+		g.AddNode(node)
+	}
+
+	numEdges, err := s.readCount("the number of edges")
+	if err != nil {
+		return nil, err
+	}
+	edgeFields, edgeShape := 2, "<from> <to>"
+	if opts.Weighted {
+		edgeFields, edgeShape = 3, "<from> <to> <weight>"
+	}
+	for i := 0; i < numEdges; i++ {
+		err = s.expectLine(fmt.Sprintf("edge %d of %d", i+1, numEdges))
+		if err != nil {
+			return nil, err
+		}
+		err = s.expectFields(edgeFields, "an edge "+edgeShape)
+		if err != nil {
+			return nil, err
+		}
+		for _, endpoint := range s.fields[:2] {
+			if !g.IsValidNode(endpoint.text) {
+				return nil, s.errorf(endpoint.column, "edge refers to undeclared node %s", endpoint.text)
+			}
+		}
+		edge := StringEdge{Frm: s.fields[0].text, To: s.fields[1].text}
+		if opts.Weighted {
+			wt, err := strconv.ParseUint(s.fields[2].text, 10, 0)
+			if err != nil {
+				return nil, s.errorf(s.fields[2].column, "expected a non negative integer weight, found %q", s.fields[2].text)
+			}
+			edge.Wt = Weight(wt)
+		}
+		g.AddEdge(edge)
+	}
+
+	queries := []Query{}
+	if opts.Batch {
+		for {
+			ok, err := s.next()
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				break
+			}
+			err = s.expectFields(2, "a query <follower> <following>")
+			if err != nil {
+				return nil, err
+			}
+			queries = append(queries, Query{Follower: s.fields[0].text, Following: s.fields[1].text})
+		}
+	} else {
+		endpoints := make([]Node, 2)
+		for i, what := range []string{"the follower", "the following"} {
+			err = s.expectLine(what)
+			if err != nil {
+				return nil, err
+			}
+			err = s.expectFields(1, what)
+			if err != nil {
+				return nil, err
+			}
+			endpoints[i] = s.fields[0].text
+		}
+		queries = append(queries, Query{Follower: endpoints[0], Following: endpoints[1]})
+		ok, err := s.next()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return nil, s.errorf(s.fields[0].column, "unexpected content after the following, use batch mode for several queries")
+		}
+	}
+	return &Problem{Graph: g, Queries: queries}, nil
+}
+
+// WriteProblem writes p in the format read by ParseProblem with the same
+// options, nodes and edges in sorted order. Weights are only written for
+// weighted problems, and a problem that is not in batch mode must have exactly
+// one query.
+func WriteProblem(w io.Writer, p *Problem, opts ProblemOptions) error {
+	if !opts.Batch && len(p.Queries) != 1 {
+		return fmt.Errorf("expected a single query outside of batch mode, got %d", len(p.Queries))
+	}
+	bw := bufio.NewWriter(w)
+	nodes := p.Graph.sortedNodes()
+	fmt.Fprintln(bw, len(nodes))
+	for _, node := range nodes {
+		fmt.Fprintln(bw, node)
+	}
+	edges := p.Graph.Edges()
+	sortEdges(edges)
+	fmt.Fprintln(bw, len(edges))
+	for _, edge := range edges {
+		if opts.Weighted {
+			fmt.Fprintln(bw, edge.Frm, edge.To, edge.Wt)
+		} else {
+			fmt.Fprintln(bw, edge.Frm, edge.To)
+		}
+	}
+	for _, q := range p.Queries {
+		if opts.Batch {
+			fmt.Fprintln(bw, q.Follower, q.Following)
+		} else {
+			fmt.Fprintln(bw, q.Follower)
+			fmt.Fprintln(bw, q.Following)
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,81 @@
+package graphProbs
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseProblem(t *testing.T) {
+	batch := ProblemOptions{Batch: true}
+	weighted := ProblemOptions{Weighted: true}
+	for _, tc := range []struct {
+		name  string
+		input string
+		opts  ProblemOptions
+		// numEdges and queries are checked when line is 0, otherwise a
+		// *ParseError at line and column is expected.
+		numEdges int
+		queries  []Query
+		line     int
+		column   int
+	}{
+		{name: "plain", input: "2\na\nb\n1\na b\na\nb\n", numEdges: 1, queries: []Query{{"a", "b"}}},
+		{name: "crlf", input: "2\r\na\r\nb\r\n1\r\na b\r\na\r\nb\r\n", numEdges: 1, queries: []Query{{"a", "b"}}},
+		{name: "no final newline", input: "2\na\nb\n1\na b\na\nb", numEdges: 1, queries: []Query{{"a", "b"}}},
+		{name: "trailing newlines", input: "2\na\nb\n1\na b\na\nb\n\n\n\r\n", numEdges: 1, queries: []Query{{"a", "b"}}},
+		{name: "extra whitespace", input: " 2 \n\ta\n\n  b\t\n1\n a   b \n a\nb\t \n", numEdges: 1, queries: []Query{{"a", "b"}}},
+		{name: "weighted", input: "2\na\nb\n1\na b 7\nb\na\n", opts: weighted, numEdges: 1, queries: []Query{{"b", "a"}}},
+		{name: "batch", input: "2\na\nb\n0\na b\r\n\nb  a\n\n", opts: batch, queries: []Query{{"a", "b"}, {"b", "a"}}},
+		{name: "batch without queries", input: "1\na\n0\n", opts: batch, queries: []Query{}},
+
+		{name: "empty", input: "", line: 1, column: 1},
+		{name: "blank", input: "\n\n", line: 3, column: 1},
+		{name: "invalid node count", input: "  two\n", line: 1, column: 3},
+		{name: "negative node count", input: "-1\n", line: 1, column: 1},
+		{name: "missing nodes", input: "2\na\n", line: 3, column: 1},
+		{name: "node with spaces", input: "2\na\nb c\n", line: 3, column: 3},
+		{name: "duplicate node", input: "2\na\n  a\n", line: 3, column: 3},
+		{name: "missing edge count", input: "1\na\n", line: 3, column: 1},
+		{name: "missing edges", input: "2\na\nb\n2\na b\n", line: 6, column: 1},
+		{name: "extra edge field", input: "2\na\nb\n1\na b 3\na\nb\n", line: 5, column: 5},
+		{name: "extra edge field crlf", input: "2\r\na\r\nb\r\n1\r\na b 3\r\n", line: 5, column: 5},
+		{name: "missing weight", input: "2\na\nb\n1\na b\na\nb\n", opts: weighted, line: 5, column: 1},
+		{name: "invalid weight", input: "2\na\nb\n1\na b -3\na\nb\n", opts: weighted, line: 5, column: 5},
+		{name: "undeclared node", input: "1\na\n1\na  z\na\na\n", line: 4, column: 4},
+		{name: "missing follower", input: "1\na\n0\n\n", line: 5, column: 1},
+		{name: "missing following", input: "2\na\nb\n1\na b\na\n", line: 7, column: 1},
+		{name: "two queries without batch", input: "1\na\n0\na\na\n\na a\n", line: 7, column: 1},
+		{name: "batch query with one node", input: "1\na\n0\na a\na\n", opts: batch, line: 5, column: 1},
+		{name: "batch query with three nodes", input: "1\na\n0\na a\n a a a\n", opts: batch, line: 5, column: 6},
+	} {
+		problem, err := ParseProblem(strings.NewReader(tc.input), tc.opts)
+		if tc.line == 0 {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tc.name, err)
+				continue
+			}
+			if len(problem.Graph.Edges()) != tc.numEdges {
+				t.Errorf("%s: expected %d edges, got %v", tc.name, tc.numEdges, problem.Graph.Edges())
+			}
+			if len(problem.Queries) != len(tc.queries) {
+				t.Errorf("%s: expected queries %v, got %v", tc.name, tc.queries, problem.Queries)
+				continue
+			}
+			for i := range tc.queries {
+				if problem.Queries[i] != tc.queries[i] {
+					t.Errorf("%s: expected queries %v, got %v", tc.name, tc.queries, problem.Queries)
+				}
+			}
+			continue
+		}
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("%s: expected a ParseError, got %v", tc.name, err)
+			continue
+		}
+		if parseErr.Line != tc.line || parseErr.Column != tc.column {
+			t.Errorf("%s: expected an error at line %d, column %d, got %v", tc.name, tc.line, tc.column, err)
+		}
+	}
+}
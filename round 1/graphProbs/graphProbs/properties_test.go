@@ -0,0 +1,419 @@
+package graphProbs_test
+
+import (
+	"errors"
+	"fmt"
+	"graphProbs/generators"
+	"graphProbs/graphProbs"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+type namedGraph struct {
+	name string
+	g    graphProbs.StringGraph
+}
+
+// sampleGraphs returns graphs of every generator with up to maxNodes nodes,
+// along with their undirected versions, for a few hundred seeds.
+func sampleGraphs(maxNodes int) []namedGraph {
+	graphs := []namedGraph{}
+	for seed := int64(1); seed <= 200; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		n := 2 + rng.Intn(maxNodes-1)
+		opts := generators.Options{Seed: seed, MaxWeight: graphProbs.Weight(rng.Intn(10))}
+		var g graphProbs.StringGraph
+		var model string
+		switch seed % 6 {
+		case 0:
+			p := rng.Float64() * 0.5
+			g, model = generators.ErdosRenyi(n, p, opts), fmt.Sprintf("ErdosRenyi(%d, %.2f)", n, p)
+		case 1:
+			m := 1 + rng.Intn(n-1)
+			g, model = generators.BarabasiAlbert(n, m, opts), fmt.Sprintf("BarabasiAlbert(%d, %d)", n, m)
+		case 2:
+			rows := 1 + rng.Intn(3)
+			cols := 1 + (n-1)/rows
+			g, model = generators.Grid(rows, cols, opts), fmt.Sprintf("Grid(%d, %d)", rows, cols)
+		case 3:
+			g, model = generators.Chain(n, opts), fmt.Sprintf("Chain(%d)", n)
+		case 4:
+			g, model = generators.Star(n, opts), fmt.Sprintf("Star(%d)", n)
+		case 5:
+			g, model = generators.CompleteDAG(n, opts), fmt.Sprintf("CompleteDAG(%d)", n)
+		}
+		name := fmt.Sprintf("%s seed %d", model, seed)
+		graphs = append(graphs, namedGraph{name: name, g: g})
+		graphs = append(graphs, namedGraph{name: "undirected " + name, g: graphProbs.MkUndirectedGraph(g.Edges(), g.Nodes())})
+	}
+	return graphs
+}
+
+func sortedNodes(g *graphProbs.StringGraph) []graphProbs.Node {
+	nodes := g.Nodes()
+	sort.Strings(nodes)
+	return nodes
+}
+
+// transitiveClosure runs Floyd-Warshall over booleans, every node reaches
+// itself.
+func transitiveClosure(g *graphProbs.StringGraph, nodes []graphProbs.Node) [][]bool {
+	index := map[graphProbs.Node]int{}
+	reach := make([][]bool, len(nodes))
+	for i, node := range nodes {
+		index[node] = i
+		reach[i] = make([]bool, len(nodes))
+		reach[i][i] = true
+	}
+	for _, edge := range g.Arcs() {
+		reach[index[edge.Frm]][index[edge.To]] = true
+	}
+	for k := range nodes {
+		for i := range nodes {
+			if !reach[i][k] {
+				continue
+			}
+			for j := range nodes {
+				if reach[k][j] {
+					reach[i][j] = true
+				}
+			}
+		}
+	}
+	return reach
+}
+
+func TestCanReachMatchesTransitiveClosure(t *testing.T) {
+	for _, tc := range sampleGraphs(25) {
+		nodes := sortedNodes(&tc.g)
+		reach := transitiveClosure(&tc.g, nodes)
+		for i, frm := range nodes {
+			for j, to := range nodes {
+				if tc.g.CanReach(frm, to) != reach[i][j] {
+					t.Errorf("%s: CanReach(%s, %s) = %v, closure says %v", tc.name, frm, to, !reach[i][j], reach[i][j])
+				}
+			}
+		}
+	}
+}
+
+// Two nodes share a strongly connected component exactly when each reaches the
+// other, and edges between components go from smaller ids to larger ones.
+func TestStronglyConnectedComponentsMatchTransitiveClosure(t *testing.T) {
+	for _, tc := range sampleGraphs(25) {
+		nodes := sortedNodes(&tc.g)
+		reach := transitiveClosure(&tc.g, nodes)
+		components := tc.g.StronglyConnectedComponents()
+		for i, u := range nodes {
+			for j, v := range nodes {
+				if components.Same(u, v) != (reach[i][j] && reach[j][i]) {
+					t.Errorf("%s: Same(%s, %s) = %v, closure says %v", tc.name, u, v, components.Same(u, v), reach[i][j] && reach[j][i])
+				}
+			}
+		}
+		for _, edge := range tc.g.Arcs() {
+			if components.Of[edge.Frm] > components.Of[edge.To] {
+				t.Errorf("%s: edge %s -> %s goes from component %d back to %d", tc.name, edge.Frm, edge.To, components.Of[edge.Frm], components.Of[edge.To])
+			}
+		}
+	}
+}
+
+func TestReachabilityIndexMatchesCanReach(t *testing.T) {
+	for _, tc := range sampleGraphs(25) {
+		nodes := sortedNodes(&tc.g)
+		idx := tc.g.BuildReachabilityIndex()
+		if idx.IsStale() {
+			t.Fatalf("%s: expected a fresh index", tc.name)
+		}
+		for _, frm := range nodes {
+			for _, to := range nodes {
+				if idx.CanReach(frm, to) != tc.g.CanReach(frm, to) {
+					t.Errorf("%s: index says CanReach(%s, %s) = %v", tc.name, frm, to, idx.CanReach(frm, to))
+				}
+			}
+		}
+		if idx.CanReach(nodes[0], "missing") || idx.CanReach("missing", nodes[0]) {
+			t.Errorf("%s: expected unknown nodes to be unreachable", tc.name)
+		}
+
+		// Once the graph changes the index falls back to searching it.
+		last := nodes[len(nodes)-1]
+		tc.g.AddEdge(graphProbs.StringEdge{Frm: last, To: "new"})
+		if !idx.IsStale() || idx.CanReach(nodes[0], "new") != tc.g.CanReach(nodes[0], "new") || !idx.CanReach(last, "new") {
+			t.Errorf("%s: expected a stale index to see the edge to a new node", tc.name)
+		}
+		err := tc.g.RemoveNode(last)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, frm := range nodes[:len(nodes)-1] {
+			for _, to := range nodes[:len(nodes)-1] {
+				if idx.CanReach(frm, to) != tc.g.CanReach(frm, to) {
+					t.Errorf("%s: after removing %s the index says CanReach(%s, %s) = %v", tc.name, last, frm, to, idx.CanReach(frm, to))
+				}
+			}
+		}
+		if idx.CanReach(last, "new") {
+			t.Errorf("%s: expected the removed node to reach nothing", tc.name)
+		}
+	}
+}
+
+// hopCounts runs a plain breadth first search from frm.
+func hopCounts(g *graphProbs.StringGraph, frm graphProbs.Node) map[graphProbs.Node]int {
+	hops := map[graphProbs.Node]int{frm: 0}
+	queue := []graphProbs.Node{frm}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, edge := range g.Neighbors(u) {
+			_, ok := hops[edge.To]
+			if !ok {
+				hops[edge.To] = hops[u] + 1
+				queue = append(queue, edge.To)
+			}
+		}
+	}
+	return hops
+}
+
+func TestBidirectionalSearchFindsFewestHops(t *testing.T) {
+	for _, tc := range sampleGraphs(25) {
+		nodes := sortedNodes(&tc.g)
+		for _, frm := range nodes {
+			hops := hopCounts(&tc.g, frm)
+			for _, to := range nodes {
+				expected, reachable := hops[to]
+				got, ok := tc.g.HopDistance(frm, to)
+				if ok != reachable || got != expected || tc.g.CanReachBidirectional(frm, to) != reachable {
+					t.Errorf("%s: HopDistance(%s, %s) = %d %v, breadth first search gives %d %v", tc.name, frm, to, got, ok, expected, reachable)
+					continue
+				}
+				path := tc.g.BidirectionalSearch(frm, to)
+				if !reachable {
+					if path != nil {
+						t.Errorf("%s: expected no path from %s to %s, got %v", tc.name, frm, to, path.Nodes)
+					}
+					continue
+				}
+				if path.Nodes[0] != frm || path.Nodes[len(path.Nodes)-1] != to || path.Hops() != expected {
+					t.Errorf("%s: path %v from %s to %s has the wrong endpoints or length", tc.name, path.Nodes, frm, to)
+				}
+				met := false
+				for i, node := range path.Nodes {
+					met = met || node == path.Meeting
+					if i > 0 && !tc.g.ImmediateParents(node)[path.Nodes[i-1]] {
+						t.Errorf("%s: path %v from %s to %s uses a missing edge %s -> %s", tc.name, path.Nodes, frm, to, path.Nodes[i-1], node)
+					}
+				}
+				if !met {
+					t.Errorf("%s: meeting node %s is not on the path %v", tc.name, path.Meeting, path.Nodes)
+				}
+			}
+		}
+	}
+}
+
+// bruteForceShortestTime enumerates every simple path from start to end, with
+// non negative weights no walk can be shorter than the best of them.
+func bruteForceShortestTime(g *graphProbs.StringGraph, start graphProbs.Node, end graphProbs.Node) *graphProbs.Weight {
+	var best *graphProbs.Weight
+	onPath := map[graphProbs.Node]bool{}
+	var extend func(node graphProbs.Node, wt graphProbs.Weight)
+	extend = func(node graphProbs.Node, wt graphProbs.Weight) {
+		if node == end {
+			if best == nil || wt < *best {
+				best = &wt
+			}
+			return
+		}
+		onPath[node] = true
+		for _, edge := range g.Neighbors(node) {
+			if !onPath[edge.To] {
+				extend(edge.To, wt+edge.Wt)
+			}
+		}
+		onPath[node] = false
+	}
+	extend(start, 0)
+	return best
+}
+
+func TestShortestTimeMatchesBruteForce(t *testing.T) {
+	for _, tc := range sampleGraphs(7) {
+		nodes := sortedNodes(&tc.g)
+		for _, start := range nodes {
+			for _, end := range nodes {
+				expected := bruteForceShortestTime(&tc.g, start, end)
+				got := tc.g.ShortestTime(start, end)
+				if (expected == nil) != (got == nil) || expected != nil && *expected != *got {
+					t.Errorf("%s: ShortestTime(%s, %s) = %s, brute force gives %s", tc.name, start, end, formatWeight(got), formatWeight(expected))
+				}
+			}
+		}
+	}
+}
+
+// Every branch of the tree from ShortestTimesFrom is a shortest path, and the
+// nodes it leaves out are exactly those ShortestPath cannot reach.
+func TestShortestTimesFromMatchesShortestPath(t *testing.T) {
+	for _, tc := range sampleGraphs(7) {
+		nodes := sortedNodes(&tc.g)
+		for _, start := range nodes {
+			tree := tc.g.ShortestTimesFrom(start)
+			unreachable := []graphProbs.Node{}
+			for _, end := range nodes {
+				expected := tc.g.ShortestPath(start, end)
+				wt, ok := tree.TimeTo(end)
+				path := tree.PathTo(end)
+				if expected == nil {
+					unreachable = append(unreachable, end)
+					if ok || path != nil || tree.IsReachable(end) {
+						t.Errorf("%s: %s is unreachable from %s, the tree gives %d %v", tc.name, end, start, wt, path)
+					}
+					continue
+				}
+				if !ok || !tree.IsReachable(end) || wt != expected.Wt {
+					t.Errorf("%s: expected the tree from %s to reach %s in %d, got %d %v", tc.name, start, end, expected.Wt, wt, ok)
+					continue
+				}
+				total := graphProbs.Weight(0)
+				at := start
+				for _, edge := range path.Edges {
+					if edge.Frm != at || !tc.g.ImmediateParents(edge.To)[edge.Frm] {
+						t.Errorf("%s: the tree path %v from %s to %s does not follow the edges of the graph", tc.name, path.Edges, start, end)
+						break
+					}
+					total += edge.Wt
+					at = edge.To
+				}
+				if at != end || total != wt || path.Wt != wt {
+					t.Errorf("%s: the tree path %v from %s to %s ends at %s and weighs %d, expected %d", tc.name, path.Edges, start, end, at, total, wt)
+				}
+			}
+			got := tree.Unreachable(&tc.g)
+			if fmt.Sprint(got) != fmt.Sprint(unreachable) {
+				t.Errorf("%s: expected %v to be unreachable from %s, got %v", tc.name, unreachable, start, got)
+			}
+		}
+		if tc.g.ShortestTimesFrom("missing") != nil {
+			t.Errorf("%s: expected no tree from a node outside the graph", tc.name)
+		}
+	}
+}
+
+func formatWeight(wt *graphProbs.Weight) string {
+	if wt == nil {
+		return "nil"
+	}
+	return fmt.Sprint(*wt)
+}
+
+// Blocking the neighbors returned by NeighborsToBlockToEnsureUnreachability
+// cuts every route from follower to following, unless follower itself is an
+// immediate parent of following, in which case it has to be among them.
+func TestBlockingNeighborsMakesFollowingUnreachable(t *testing.T) {
+	for _, tc := range sampleGraphs(25) {
+		nodes := sortedNodes(&tc.g)
+		for _, follower := range nodes {
+			for _, following := range nodes {
+				if follower == following {
+					continue
+				}
+				blocked := map[graphProbs.Node]bool{}
+				for node := range tc.g.NeighborsToBlockToEnsureUnreachability(follower, following) {
+					blocked[node] = true
+				}
+				if tc.g.ImmediateParents(following)[follower] {
+					if !blocked[follower] {
+						t.Errorf("%s: %s follows %s directly but is not among the neighbors to block", tc.name, follower, following)
+					}
+					continue
+				}
+				for node := range tc.g.ReachableNodes(follower, blocked) {
+					if node == following {
+						t.Errorf("%s: %s still reaches %s after blocking %v", tc.name, follower, following, blocked)
+					}
+				}
+			}
+		}
+	}
+}
+
+func reachesAvoiding(g *graphProbs.StringGraph, frm graphProbs.Node, to graphProbs.Node, blocked map[graphProbs.Node]bool) bool {
+	reached := false
+	g.WalkReachableNodes(frm, blocked, func(node graphProbs.Node) bool {
+		reached = node == to
+		return !reached
+	})
+	return reached
+}
+
+// bruteForceVertexCutSize tries every set of nodes other than follower and
+// following by increasing size, and returns the size of the first one that
+// cuts every route, or -1 if there is none.
+func bruteForceVertexCutSize(g *graphProbs.StringGraph, nodes []graphProbs.Node, follower graphProbs.Node, following graphProbs.Node) int {
+	others := []graphProbs.Node{}
+	for _, node := range nodes {
+		if node != follower && node != following {
+			others = append(others, node)
+		}
+	}
+	best := -1
+	for mask := 0; mask < 1<<len(others); mask++ {
+		blocked := map[graphProbs.Node]bool{}
+		for i, node := range others {
+			if mask&(1<<i) != 0 {
+				blocked[node] = true
+			}
+		}
+		if (best < 0 || len(blocked) < best) && !reachesAvoiding(g, follower, following, blocked) {
+			best = len(blocked)
+		}
+	}
+	return best
+}
+
+func TestMinimumVertexCutMatchesBruteForce(t *testing.T) {
+	for _, tc := range sampleGraphs(7) {
+		nodes := sortedNodes(&tc.g)
+		for _, follower := range nodes {
+			for _, following := range nodes {
+				cut, err := tc.g.MinimumVertexCut(follower, following)
+				if follower == following {
+					var sameErr *graphProbs.SameEndpointsError[graphProbs.Node]
+					if !errors.As(err, &sameErr) {
+						t.Errorf("%s: expected a SameEndpointsError for %s, got %v", tc.name, follower, err)
+					}
+					continue
+				}
+				if tc.g.ImmediateParents(following)[follower] {
+					var directErr *graphProbs.DirectEdgeError[graphProbs.Node]
+					if !errors.As(err, &directErr) {
+						t.Errorf("%s: expected a DirectEdgeError for %s -> %s, got %v", tc.name, follower, following, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("%s: %v", tc.name, err)
+				}
+				blocked := map[graphProbs.Node]bool{}
+				for _, node := range cut {
+					if node == follower || node == following {
+						t.Errorf("%s: cut %v between %s and %s contains an endpoint", tc.name, cut, follower, following)
+					}
+					blocked[node] = true
+				}
+				if reachesAvoiding(&tc.g, follower, following, blocked) {
+					t.Errorf("%s: %s still reaches %s after blocking %v", tc.name, follower, following, cut)
+				}
+				expected := bruteForceVertexCutSize(&tc.g, nodes, follower, following)
+				if len(cut) != expected {
+					t.Errorf("%s: cut %v between %s and %s has %d nodes, brute force finds %d", tc.name, cut, follower, following, len(cut), expected)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package graphProbs
+
+// ReachabilityIndex answers CanReach queries from the transitive closure of
+// the condensation, kept as one bitset of reachable components per component.
+// The closure is only valid for the version of the graph it was built from,
+// once the graph changes queries fall back to a breadth first search.
+type ReachabilityIndex[N Ordered, W Number] struct {
+	g          *Graph[N, W]
+	version    uint64
+	components *Components[N]
+	closure    [][]uint64
+}
+
+func (g *Graph[N, W]) BuildReachabilityIndex() *ReachabilityIndex[N, W] {
+	condensed, components := g.Condensation()
+	numComponents := components.Count()
+	words := (numComponents + 63) / 64
+	closure := make([][]uint64, numComponents)
+	// Component ids are topologically ordered, so every successor of c has a
+	// larger id and its closure is complete by the time c is processed.
+	for c := numComponents - 1; c >= 0; c-- {
+		closure[c] = make([]uint64, words)
+		closure[c][c/64] |= 1 << (c % 64)
+		for _, neighbor := range condensed.Neighbors(c) {
+			for i, word := range closure[neighbor.To] {
+				closure[c][i] |= word
+			}
+		}
+	}
+	return &ReachabilityIndex[N, W]{
+		g:          g,
+		version:    g.Version(),
+		components: components,
+		closure:    closure,
+	}
+}
+
+func (idx *ReachabilityIndex[N, W]) IsStale() bool {
+	return idx.version != idx.g.Version()
+}
+
+func (idx *ReachabilityIndex[N, W]) CanReach(frm N, to N) bool {
+	if idx.IsStale() {
+		return idx.g.CanReach(frm, to)
+	}
+	cfrm, ok := idx.components.Of[frm]
+	if !ok {
+		return idx.g.CanReach(frm, to)
+	}
+	cto, ok := idx.components.Of[to]
+	if !ok {
+		return false
+	}
+	return idx.closure[cfrm][cto/64]&(1<<(cto%64)) != 0
+}
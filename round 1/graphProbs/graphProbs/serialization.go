@@ -0,0 +1,281 @@
+package graphProbs
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+type ValidationError struct {
+	// Kind is "duplicate node", "duplicate edge", "dangling edge", "invalid
+	// weight" or "invalid id".
+	Kind  string
+	Index int
+	Msg   string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s at index %d: %s", e.Kind, e.Index, e.Msg)
+}
+
+type jsonEdge[N Ordered, W Number] struct {
+	Frm    N        `json:"from"`
+	To     N        `json:"to"`
+	Wt     W        `json:"weight"`
+	Id     EdgeId   `json:"id,omitempty"`
+	Labels []string `json:"labels,omitempty"`
+}
+
+type jsonGraph[N Ordered, W Number] struct {
+	Undirected bool             `json:"undirected,omitempty"`
+	Multi      bool             `json:"multi,omitempty"`
+	Nodes      []N              `json:"nodes"`
+	Edges      []jsonEdge[N, W] `json:"edges"`
+}
+
+// mkValidatedGraph builds a graph from an explicit node list, rejecting edges
+// that repeat an earlier Frm -> To pair, in either order for undirected
+// graphs, or mention a node not in the list. Multigraphs allow repeated pairs
+// but reject repeated edge ids, and keep labels[i], if given, on edges[i].
+func mkValidatedGraph[N Ordered, W Number](opts GraphOptions, nodes []N, edges []Edge[N, W], labels [][]string) (Graph[N, W], error) {
+	g := MkGraphWithOptions[N, W](opts, nil, nil)
+	for i, node := range nodes {
+		if g.IsValidNode(node) {
+			return Graph[N, W]{}, &ValidationError{Kind: "duplicate node", Index: i, Msg: fmt.Sprintf("node %v is listed more than once", node)}
+		}
+		g.AddNode(node)
+	}
+	if g.multi {
+		ids := map[EdgeId]bool{}
+		for i, edge := range edges {
+			if edge.Id == 0 {
+				continue
+			}
+			if ids[edge.Id] {
+				return Graph[N, W]{}, &ValidationError{Kind: "duplicate edge", Index: i, Msg: fmt.Sprintf("edge id %d is used more than once", edge.Id)}
+			}
+			ids[edge.Id] = true
+		}
+		g.reserveEdgeIds(edges)
+	}
+	for i, edge := range edges {
+		for _, node := range []N{edge.Frm, edge.To} {
+			if !g.IsValidNode(node) {
+				return Graph[N, W]{}, &ValidationError{Kind: "dangling edge", Index: i, Msg: fmt.Sprintf("edge %v -> %v refers to undeclared node %v", edge.Frm, edge.To, node)}
+			}
+		}
+		if g.multi {
+			id := g.addEdge(edge)
+			if i < len(labels) {
+				g.SetLabels(id, labels[i]...)
+			}
+			continue
+		}
+		_, ok := g.adjacencyMatrix[edge.Frm][edge.To]
+		if ok {
+			return Graph[N, W]{}, &ValidationError{Kind: "duplicate edge", Index: i, Msg: fmt.Sprintf("edge %v -> %v is listed more than once", edge.Frm, edge.To)}
+		}
+		g.AddEdge(edge)
+	}
+	return g, nil
+}
+
+func (g *Graph[N, W]) WriteJSON(w io.Writer) error {
+	edges := g.Edges()
+	sortEdges(edges)
+	jg := jsonGraph[N, W]{Undirected: g.undirected, Multi: g.multi, Nodes: g.sortedNodes(), Edges: make([]jsonEdge[N, W], len(edges))}
+	for i, edge := range edges {
+		jg.Edges[i] = jsonEdge[N, W]{Frm: edge.Frm, To: edge.To, Wt: edge.Wt, Id: edge.Id, Labels: g.Labels(edge.Id)}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(jg)
+}
+
+func ReadJSON(r io.Reader) (StringGraph, error) {
+	jg := jsonGraph[Node, Weight]{}
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&jg)
+	if err != nil {
+		return StringGraph{}, err
+	}
+	edges := make([]StringEdge, len(jg.Edges))
+	labels := make([][]string, len(jg.Edges))
+	for i, edge := range jg.Edges {
+		edges[i] = StringEdge{Frm: edge.Frm, To: edge.To, Wt: edge.Wt, Id: edge.Id}
+		labels[i] = edge.Labels
+	}
+	return mkValidatedGraph(GraphOptions{Undirected: jg.Undirected, Multi: jg.Multi}, jg.Nodes, edges, labels)
+}
+
+const graphMLNamespace = "http://graphml.graphdrawing.org/xmlns"
+const graphMLWeightKey = "weight"
+const graphMLIdKey = "id"
+const graphMLMultiKey = "multi"
+const graphMLLabelKey = "label"
+
+func graphMLWeightType[W Number]() string {
+	switch reflect.TypeOf(W(0)).Kind() {
+	case reflect.Float32, reflect.Float64:
+		return "double"
+	}
+	return "long"
+}
+
+type graphMLKey struct {
+	Id       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+type graphMLNode struct {
+	Id string `xml:"id,attr"`
+}
+
+type graphMLEdge struct {
+	Id     string        `xml:"id,attr,omitempty"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLGraph struct {
+	Id          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	EdgeIds     string        `xml:"parse.edgeids,attr,omitempty"`
+	Data        []graphMLData `xml:"data"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLDocument struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+func (g *Graph[N, W]) WriteGraphML(w io.Writer) error {
+	doc := graphMLDocument{
+		Xmlns: graphMLNamespace,
+		Keys:  []graphMLKey{{Id: graphMLWeightKey, For: "edge", AttrName: "weight", AttrType: graphMLWeightType[W]()}},
+		Graph: graphMLGraph{Id: "G", EdgeDefault: "directed"},
+	}
+	if g.undirected {
+		doc.Graph.EdgeDefault = "undirected"
+	}
+	if g.multi {
+		doc.Keys = append(doc.Keys,
+			graphMLKey{Id: graphMLIdKey, For: "edge", AttrName: "id", AttrType: "long"},
+			graphMLKey{Id: graphMLLabelKey, For: "edge", AttrName: "label", AttrType: "string"},
+			graphMLKey{Id: graphMLMultiKey, For: "graph", AttrName: "multi", AttrType: "boolean"})
+		doc.Graph.EdgeIds = "free"
+		doc.Graph.Data = []graphMLData{{Key: graphMLMultiKey, Value: "true"}}
+	}
+	for _, node := range g.sortedNodes() {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{Id: fmt.Sprint(node)})
+	}
+	edges := g.Edges()
+	sortEdges(edges)
+	for _, edge := range edges {
+		graphMLEdge := graphMLEdge{
+			Source: fmt.Sprint(edge.Frm),
+			Target: fmt.Sprint(edge.To),
+			Data:   []graphMLData{{Key: graphMLWeightKey, Value: fmt.Sprint(edge.Wt)}},
+		}
+		if g.multi {
+			graphMLEdge.Id = fmt.Sprintf("e%d", edge.Id)
+			graphMLEdge.Data = append(graphMLEdge.Data, graphMLData{Key: graphMLIdKey, Value: fmt.Sprint(edge.Id)})
+			for _, label := range g.Labels(edge.Id) {
+				graphMLEdge.Data = append(graphMLEdge.Data, graphMLData{Key: graphMLLabelKey, Value: label})
+			}
+		}
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge)
+	}
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	err = encoder.Encode(doc)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// ReadGraphML reads a single graph, edge weights are taken from the data
+// element whose key is declared with attr.name "weight" and default to 0. A
+// graph with edgedefault="undirected" is read as an undirected Graph, and one
+// whose "multi" data is true as a multigraph, with edge ids taken from the
+// data whose key has attr.name "id" and one label from every data whose key
+// has attr.name "label".
+func ReadGraphML(r io.Reader) (StringGraph, error) {
+	doc := graphMLDocument{}
+	err := xml.NewDecoder(r).Decode(&doc)
+	if err != nil {
+		return StringGraph{}, err
+	}
+	weightKeys := map[string]bool{}
+	idKeys := map[string]bool{}
+	multiKeys := map[string]bool{}
+	labelKeys := map[string]bool{}
+	for _, key := range doc.Keys {
+		switch {
+		case key.AttrName == "weight" && (key.For == "edge" || key.For == "all"):
+			weightKeys[key.Id] = true
+		case key.AttrName == "id" && (key.For == "edge" || key.For == "all"):
+			idKeys[key.Id] = true
+		case key.AttrName == "label" && (key.For == "edge" || key.For == "all"):
+			labelKeys[key.Id] = true
+		case key.AttrName == "multi" && (key.For == "graph" || key.For == "all"):
+			multiKeys[key.Id] = true
+		}
+	}
+	opts := GraphOptions{Undirected: doc.Graph.EdgeDefault == "undirected"}
+	for _, data := range doc.Graph.Data {
+		if multiKeys[data.Key] {
+			opts.Multi = data.Value == "true" || data.Value == "1"
+		}
+	}
+	nodes := make([]Node, len(doc.Graph.Nodes))
+	for i, node := range doc.Graph.Nodes {
+		nodes[i] = node.Id
+	}
+	edges := make([]StringEdge, len(doc.Graph.Edges))
+	labels := make([][]string, len(doc.Graph.Edges))
+	for i, edge := range doc.Graph.Edges {
+		edges[i] = StringEdge{Frm: edge.Source, To: edge.Target}
+		for _, data := range edge.Data {
+			switch {
+			case weightKeys[data.Key]:
+				wt, err := strconv.ParseUint(data.Value, 10, 0)
+				if err != nil {
+					return StringGraph{}, &ValidationError{Kind: "invalid weight", Index: i, Msg: fmt.Sprintf("edge %s -> %s has weight %q", edge.Source, edge.Target, data.Value)}
+				}
+				edges[i].Wt = Weight(wt)
+			case idKeys[data.Key] && opts.Multi:
+				id, err := strconv.ParseUint(data.Value, 10, 64)
+				if err != nil {
+					return StringGraph{}, &ValidationError{Kind: "invalid id", Index: i, Msg: fmt.Sprintf("edge %s -> %s has id %q", edge.Source, edge.Target, data.Value)}
+				}
+				edges[i].Id = id
+			case labelKeys[data.Key] && opts.Multi:
+				labels[i] = append(labels[i], data.Value)
+			}
+		}
+	}
+	return mkValidatedGraph(opts, nodes, edges, labels)
+}
@@ -0,0 +1,77 @@
+package graphProbs
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestJSONAndGraphMLRoundTrip(t *testing.T) {
+	for name, g := range roundTripGraphs() {
+		var buf bytes.Buffer
+		err := g.WriteJSON(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		read, err := ReadJSON(&buf)
+		if err != nil {
+			t.Fatalf("json %s: %v", name, err)
+		}
+		assertSameGraph(t, "json "+name, &g, &read)
+
+		buf.Reset()
+		err = g.WriteGraphML(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		read, err = ReadGraphML(&buf)
+		if err != nil {
+			t.Fatalf("graphml %s: %v", name, err)
+		}
+		assertSameGraph(t, "graphml "+name, &g, &read)
+	}
+}
+
+func TestReadJSONValidation(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		kind  string
+		index int
+	}{
+		{`{"nodes": ["a", "b", "a"], "edges": []}`, "duplicate node", 2},
+		{`{"nodes": ["a"], "edges": [{"from": "a", "to": "b", "weight": 1}]}`, "dangling edge", 0},
+		{`{"nodes": ["a", "b"], "edges": [{"from": "a", "to": "b"}, {"from": "b", "to": "a"}, {"from": "a", "to": "b"}]}`, "duplicate edge", 2},
+		{`{"undirected": true, "nodes": ["a", "b"], "edges": [{"from": "a", "to": "b"}, {"from": "b", "to": "a"}]}`, "duplicate edge", 1},
+	} {
+		_, err := ReadJSON(strings.NewReader(tc.input))
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Kind != tc.kind || validationErr.Index != tc.index {
+			t.Errorf("%s: expected a %s error at index %d, got %v", tc.input, tc.kind, tc.index, err)
+		}
+	}
+	_, err := ReadJSON(strings.NewReader(`{"nodes": [], "edges": [], "weighted": true}`))
+	if err == nil {
+		t.Error("expected unknown fields to be rejected")
+	}
+}
+
+func TestReadGraphMLValidation(t *testing.T) {
+	const header = `<graphml xmlns="http://graphml.graphdrawing.org/xmlns"><key id="w" for="edge" attr.name="weight" attr.type="long"/><graph edgedefault="directed">`
+	for _, tc := range []struct {
+		input string
+		kind  string
+		index int
+	}{
+		{`<node id="a"/><node id="a"/>`, "duplicate node", 1},
+		{`<node id="a"/><edge source="a" target="b"/>`, "dangling edge", 0},
+		{`<node id="a"/><node id="b"/><edge source="a" target="b"/><edge source="a" target="b"/>`, "duplicate edge", 1},
+		{`<node id="a"/><node id="b"/><edge source="a" target="b"><data key="w">-2</data></edge>`, "invalid weight", 0},
+	} {
+		_, err := ReadGraphML(strings.NewReader(header + tc.input + `</graph></graphml>`))
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Kind != tc.kind || validationErr.Index != tc.index {
+			t.Errorf("%s: expected a %s error at index %d, got %v", tc.input, tc.kind, tc.index, err)
+		}
+	}
+}
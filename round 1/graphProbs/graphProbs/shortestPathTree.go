@@ -0,0 +1,56 @@
+package graphProbs
+
+type ShortestPathTree[N Ordered, W Number] struct {
+	Root  N
+	Dists map[N]W
+	Preds map[N]Edge[N, W]
+}
+
+// ShortestTimesFrom returns nil if start is not part of g. Weights must not be
+// negative, CheckedShortestTimesFrom reports a negative one instead.
+func (g *Graph[N, W]) ShortestTimesFrom(start N) *ShortestPathTree[N, W] {
+	if !g.IsValidNode(start) {
+		return nil
+	}
+	dists, preds := g.dijkstra(start, nil, nil, nil)
+	return &ShortestPathTree[N, W]{Root: start, Dists: dists, Preds: preds}
+}
+
+// CheckedShortestTimesFrom is ShortestTimesFrom returning a
+// *NegativeWeightError if g has a negative edge.
+func (g *Graph[N, W]) CheckedShortestTimesFrom(start N) (*ShortestPathTree[N, W], error) {
+	err := g.CheckWeightsNonNegative()
+	if err != nil {
+		return nil, err
+	}
+	return g.ShortestTimesFrom(start), nil
+}
+
+func (t *ShortestPathTree[N, W]) IsReachable(n N) bool {
+	_, ok := t.Dists[n]
+	return ok
+}
+
+func (t *ShortestPathTree[N, W]) TimeTo(n N) (W, bool) {
+	wt, ok := t.Dists[n]
+	return wt, ok
+}
+
+func (t *ShortestPathTree[N, W]) PathTo(n N) *Path[N, W] {
+	wt, ok := t.Dists[n]
+	if !ok {
+		return nil
+	}
+	return tracePath(t.Preds, t.Root, n, wt)
+}
+
+func (t *ShortestPathTree[N, W]) Unreachable(g *Graph[N, W]) []N {
+	unreachable := []N{}
+	for _, node := range g.Nodes() {
+		if !t.IsReachable(node) {
+			unreachable = append(unreachable, node)
+		}
+	}
+	sortNodes(unreachable)
+	return unreachable
+}
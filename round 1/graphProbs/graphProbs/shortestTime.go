@@ -2,28 +2,34 @@ package graphProbs
 
 import (
 	"container/heap"
-	"math"
+	"fmt"
 )
 
-type pair struct {
-	node   Node
-	weight Weight
+type pair[N Ordered, W Number] struct {
+	node   N
+	weight W
 }
 
-// An pairHeap is a min-heap of Weights.
-type pairHeap []pair
+// An pairHeap is a min-heap of Weights, ties are broken on the Node so that
+// the order in which nodes are settled does not depend on map iteration.
+type pairHeap[N Ordered, W Number] []pair[N, W]
 
-func (h pairHeap) Len() int           { return len(h) }
-func (h pairHeap) Less(i, j int) bool { return h[i].weight < h[j].weight }
-func (h pairHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h pairHeap[N, W]) Len() int { return len(h) }
+func (h pairHeap[N, W]) Less(i, j int) bool {
+	if h[i].weight != h[j].weight {
+		return h[i].weight < h[j].weight
+	}
+	return h[i].node < h[j].node
+}
+func (h pairHeap[N, W]) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
 
-func (h *pairHeap) Push(x any) {
+func (h *pairHeap[N, W]) Push(x any) {
 	// Push and Pop use pointer receivers because they modify the slice's length,
 	// not just its contents.
-	*h = append(*h, x.(pair))
+	*h = append(*h, x.(pair[N, W]))
 }
 
-func (h *pairHeap) Pop() any {
+func (h *pairHeap[N, W]) Pop() any {
 	old := *h
 	n := len(old)
 	x := old[n-1]
@@ -31,47 +37,148 @@ func (h *pairHeap) Pop() any {
 	return x
 }
 
-func (g *Graph) ShortestTime(start Node, end Node) *Weight {
-	if !g.IsValidNode(start) || !g.IsValidNode(end) {
+// Path keeps its Start so that a path without edges still has a node.
+type Path[N Ordered, W Number] struct {
+	Start N
+	Edges []Edge[N, W]
+	Wt    W
+}
+
+func (p *Path[N, W]) Nodes() []N {
+	nodes := make([]N, len(p.Edges)+1)
+	nodes[0] = p.Start
+	for i, edge := range p.Edges {
+		nodes[i+1] = edge.To
+	}
+	return nodes
+}
+
+type NegativeWeightError[N Ordered, W Number] struct {
+	Edge Edge[N, W]
+}
+
+func (e *NegativeWeightError[N, W]) Error() string {
+	return fmt.Sprintf("edge %v -> %v has negative weight %v, use BellmanFord", e.Edge.Frm, e.Edge.To, e.Edge.Wt)
+}
+
+// CheckWeightsNonNegative returns a *NegativeWeightError for the negative edge
+// that sorts first, if any. Negative edges are counted as they are added, so
+// only a graph that has one is scanned.
+func (g *Graph[N, W]) CheckWeightsNonNegative() error {
+	if *g.negativeEdges == 0 {
 		return nil
 	}
-	dists := map[Node]Weight{}
-	pq := make(pairHeap, len(g.nodes))
-	pq[0] = pair{node: start, weight: 0}
-	dists[start] = 0
-	i := 1
-	for node := range g.nodes {
-		if node == start {
-			continue
+	var negative *Edge[N, W]
+	for u, assocs := range g.adjacencyMatrix {
+		for v, wt := range assocs {
+			if wt < 0 && (negative == nil || u < negative.Frm || u == negative.Frm && v < negative.To) {
+				negative = &Edge[N, W]{Frm: u, To: v, Wt: wt}
+			}
 		}
-		dists[node] = uint(math.Inf(0))
-		pq[i] = pair{node, uint(math.Inf(0))}
-		i += 1
 	}
-	visited := map[Node]bool{}
+	if negative == nil {
+		return nil
+	}
+	for _, edge := range g.parallelEdges[negative.Frm][negative.To] {
+		if edge.Wt == negative.Wt {
+			negative.Id = edge.Id
+			break
+		}
+	}
+	return &NegativeWeightError[N, W]{Edge: *negative}
+}
+
+// dijkstra settles nodes reachable from start in (weight, node) order, if end
+// is not nil it stops as soon as end is settled. A node's predecessor edge is
+// only replaced on a strict improvement, so the first settled of several
+// equally short routes wins, and of parallel edges the cheapest is taken.
+// Blocked nodes and edges are never relaxed. Callers check that weights are
+// not negative first.
+func (g *Graph[N, W]) dijkstra(start N, end *N, blockedNodes map[N]bool, blockedEdges map[Edge[N, W]]bool) (map[N]W, map[N]Edge[N, W]) {
+	dists := map[N]W{start: 0}
+	preds := map[N]Edge[N, W]{}
+	pq := pairHeap[N, W]{pair[N, W]{node: start, weight: 0}}
+	visited := map[N]bool{}
 	for {
 		if len(pq) == 0 {
 			break
 		}
-		pr := heap.Pop(&pq).(pair)
-		if pr.node == end {
-			return &pr.weight
+		pr := heap.Pop(&pq).(pair[N, W])
+		_, ok := visited[pr.node]
+		if ok {
+			continue
 		}
 		visited[pr.node] = true
+		if end != nil && pr.node == *end {
+			break
+		}
 		du := pr.weight
 		for _, neighbor := range g.Neighbors(pr.node) {
 			vnode := neighbor.To
-			wt := neighbor.Wt
 			_, ok := visited[vnode]
-			if ok {
+			if ok || blockedNodes[vnode] || blockedEdges[neighbor] {
 				continue
 			}
-			dv := dists[vnode]
-			if du+wt <= dv {
-				dists[vnode] = du + wt
-				heap.Push(&pq, pair{node: vnode, weight: du + wt})
+			dv, ok := dists[vnode]
+			if !ok || du+neighbor.Wt < dv {
+				dists[vnode] = du + neighbor.Wt
+				preds[vnode] = neighbor
+				heap.Push(&pq, pair[N, W]{node: vnode, weight: du + neighbor.Wt})
 			}
 		}
 	}
-	return nil
+	return dists, preds
+}
+
+func tracePath[N Ordered, W Number](preds map[N]Edge[N, W], start N, end N, wt W) *Path[N, W] {
+	edges := []Edge[N, W]{}
+	for node := end; node != start; {
+		edge := preds[node]
+		edges = append(edges, edge)
+		node = edge.Frm
+	}
+	for i, j := 0, len(edges)-1; i < j; i, j = i+1, j-1 {
+		edges[i], edges[j] = edges[j], edges[i]
+	}
+	return &Path[N, W]{Start: start, Edges: edges, Wt: wt}
+}
+
+// ShortestPath returns nil if end cannot be reached from start. Weights must
+// not be negative, CheckedShortestPath reports a negative one instead.
+func (g *Graph[N, W]) ShortestPath(start N, end N) *Path[N, W] {
+	if !g.IsValidNode(start) || !g.IsValidNode(end) {
+		return nil
+	}
+	dists, preds := g.dijkstra(start, &end, nil, nil)
+	wt, ok := dists[end]
+	if !ok {
+		return nil
+	}
+	return tracePath(preds, start, end, wt)
+}
+
+func (g *Graph[N, W]) ShortestTime(start N, end N) *W {
+	path := g.ShortestPath(start, end)
+	if path == nil {
+		return nil
+	}
+	return &path.Wt
+}
+
+// CheckedShortestPath is ShortestPath returning a *NegativeWeightError if g
+// has a negative edge.
+func (g *Graph[N, W]) CheckedShortestPath(start N, end N) (*Path[N, W], error) {
+	err := g.CheckWeightsNonNegative()
+	if err != nil {
+		return nil, err
+	}
+	return g.ShortestPath(start, end), nil
+}
+
+func (g *Graph[N, W]) CheckedShortestTime(start N, end N) (*W, error) {
+	err := g.CheckWeightsNonNegative()
+	if err != nil {
+		return nil, err
+	}
+	return g.ShortestTime(start, end), nil
 }
@@ -0,0 +1,135 @@
+package graphProbs
+
+type ComponentId = int
+
+// Components assigns every node to a strongly connected component. Ids are in
+// topological order of the condensation, so an edge between two different
+// components always goes from a smaller id to a larger one.
+type Components[N Ordered] struct {
+	Of      map[N]ComponentId
+	Members [][]N
+}
+
+func (c *Components[N]) Count() int {
+	return len(c.Members)
+}
+
+func (c *Components[N]) Same(u N, v N) bool {
+	cu, ok := c.Of[u]
+	if !ok {
+		return false
+	}
+	cv, ok := c.Of[v]
+	return ok && cu == cv
+}
+
+func (g *Graph[N, W]) sortedNeighbors(n N) []Edge[N, W] {
+	neighbors := g.Neighbors(n)
+	sortEdges(neighbors)
+	return neighbors
+}
+
+type tarjanFrame[N Ordered, W Number] struct {
+	node      N
+	neighbors []Edge[N, W]
+	next      int
+}
+
+// StronglyConnectedComponents runs an iterative Tarjan's algorithm, visiting
+// nodes and neighbors in sorted order so that the ids are reproducible.
+func (g *Graph[N, W]) StronglyConnectedComponents() *Components[N] {
+	index := map[N]int{}
+	lowLink := map[N]int{}
+	onStack := map[N]bool{}
+	stack := []N{}
+	found := [][]N{}
+
+	for _, root := range g.sortedNodes() {
+		_, ok := index[root]
+		if ok {
+			continue
+		}
+		index[root] = len(index)
+		lowLink[root] = index[root]
+		stack = append(stack, root)
+		onStack[root] = true
+		frames := []tarjanFrame[N, W]{{node: root, neighbors: g.sortedNeighbors(root)}}
+		for len(frames) > 0 {
+			frame := &frames[len(frames)-1]
+			if frame.next < len(frame.neighbors) {
+				v := frame.neighbors[frame.next].To
+				frame.next++
+				_, ok := index[v]
+				if !ok {
+					index[v] = len(index)
+					lowLink[v] = index[v]
+					stack = append(stack, v)
+					onStack[v] = true
+					frames = append(frames, tarjanFrame[N, W]{node: v, neighbors: g.sortedNeighbors(v)})
+				} else if onStack[v] && index[v] < lowLink[frame.node] {
+					lowLink[frame.node] = index[v]
+				}
+				continue
+			}
+
+			u := frame.node
+			frames = frames[:len(frames)-1]
+			if len(frames) > 0 {
+				parent := frames[len(frames)-1].node
+				if lowLink[u] < lowLink[parent] {
+					lowLink[parent] = lowLink[u]
+				}
+			}
+			if lowLink[u] != index[u] {
+				continue
+			}
+			members := []N{}
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				members = append(members, w)
+				if w == u {
+					break
+				}
+			}
+			sortNodes(members)
+			found = append(found, members)
+		}
+	}
+
+	// Tarjan completes components in reverse topological order.
+	components := &Components[N]{Of: map[N]ComponentId{}, Members: make([][]N, len(found))}
+	for i, members := range found {
+		id := len(found) - 1 - i
+		components.Members[id] = members
+		for _, node := range members {
+			components.Of[node] = id
+		}
+	}
+	return components
+}
+
+// Condensation contracts every strongly connected component into a single node
+// named by its ComponentId. Edges between components keep the smallest weight
+// of the edges they replace, the resulting graph is acyclic.
+func (g *Graph[N, W]) Condensation() (Graph[ComponentId, W], *Components[N]) {
+	components := g.StronglyConnectedComponents()
+	nodes := make([]ComponentId, components.Count())
+	for id := range nodes {
+		nodes[id] = id
+	}
+	condensed := MkGraph[ComponentId, W](nil, nodes)
+	for _, edge := range g.Edges() {
+		frm := components.Of[edge.Frm]
+		to := components.Of[edge.To]
+		if frm == to {
+			continue
+		}
+		wt, ok := condensed.adjacencyMatrix[frm][to]
+		if !ok || edge.Wt < wt {
+			condensed.AddEdge(Edge[ComponentId, W]{Frm: frm, To: to, Wt: edge.Wt})
+		}
+	}
+	return condensed, components
+}
@@ -0,0 +1,56 @@
+package graphProbs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStronglyConnectedComponentsWithCycles(t *testing.T) {
+	g := MkGraph([]StringEdge{
+		{Frm: "a", To: "b", Wt: 1},
+		{Frm: "b", To: "c", Wt: 1},
+		{Frm: "c", To: "a", Wt: 1},
+		{Frm: "c", To: "d", Wt: 5},
+		{Frm: "a", To: "d", Wt: 2},
+		{Frm: "d", To: "e", Wt: 1},
+		{Frm: "e", To: "d", Wt: 1},
+		{Frm: "e", To: "f", Wt: 3},
+		{Frm: "b", To: "g", Wt: 4},
+		{Frm: "g", To: "g", Wt: 1},
+		{Frm: "f", To: "g", Wt: 6},
+	}, []Node{"h"})
+	components := g.StronglyConnectedComponents()
+	groups := []string{}
+	for _, members := range components.Members {
+		groups = append(groups, strings.Join(members, ""))
+	}
+	expected := map[string]bool{"abc": true, "de": true, "f": true, "g": true, "h": true}
+	if len(groups) != len(expected) {
+		t.Fatalf("expected components %v, got %v", expected, groups)
+	}
+	for _, group := range groups {
+		if !expected[group] {
+			t.Errorf("expected components %v, got %v", expected, groups)
+		}
+	}
+	if !components.Same("a", "c") || !components.Same("e", "d") || components.Same("c", "d") || components.Same("a", "missing") {
+		t.Errorf("unexpected Same for components %v", groups)
+	}
+	for _, edge := range g.Edges() {
+		frm, to := components.Of[edge.Frm], components.Of[edge.To]
+		if frm > to {
+			t.Errorf("edge %s -> %s goes from component %d back to %d", edge.Frm, edge.To, frm, to)
+		}
+	}
+
+	condensed, _ := g.Condensation()
+	_, err := condensed.TopologicalSort()
+	if err != nil {
+		t.Fatalf("expected an acyclic condensation, got %v", err)
+	}
+	abc, de := components.Of["a"], components.Of["d"]
+	wt, ok := condensed.adjacencyMatrix[abc][de]
+	if !ok || wt != 2 || len(condensed.Edges()) != 4 {
+		t.Errorf("expected 4 edges with abc -> de keeping the smaller weight 2, got %v", condensed.Edges())
+	}
+}
@@ -0,0 +1,144 @@
+package graphProbs
+
+import (
+	"container/heap"
+	"fmt"
+	"strings"
+)
+
+type CycleError[N Ordered] struct {
+	Cycle []N
+}
+
+func (e *CycleError[N]) Error() string {
+	nodes := append(append([]N{}, e.Cycle...), e.Cycle[0])
+	return fmt.Sprintf("graph is not acyclic, found cycle: %s", strings.Join(formatNodes(nodes), " -> "))
+}
+
+// UndirectedGraphError is returned by the topological sorts of an undirected
+// graph, which has no edge direction to order the nodes by.
+type UndirectedGraphError struct{}
+
+func (e *UndirectedGraphError) Error() string {
+	return "graph is undirected, a topological sort needs a directed graph"
+}
+
+// A nodeHeap is a min-heap of Nodes.
+type nodeHeap[N Ordered] []N
+
+func (h nodeHeap[N]) Len() int           { return len(h) }
+func (h nodeHeap[N]) Less(i, j int) bool { return h[i] < h[j] }
+func (h nodeHeap[N]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *nodeHeap[N]) Push(x any) {
+	*h = append(*h, x.(N))
+}
+
+func (h *nodeHeap[N]) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[0 : n-1]
+	return x
+}
+
+// TopologicalSort orders the nodes so that every edge goes from an earlier
+// node to a later one, using Kahn's algorithm. If the graph has a cycle a
+// *CycleError carrying one of them is returned instead, and an undirected
+// graph gives an *UndirectedGraphError.
+func (g *Graph[N, W]) TopologicalSort() ([]N, error) {
+	return g.kahn(false)
+}
+
+// LexicographicTopologicalSort returns the lexicographically smallest of all
+// topological orderings, which unlike TopologicalSort is reproducible.
+func (g *Graph[N, W]) LexicographicTopologicalSort() ([]N, error) {
+	return g.kahn(true)
+}
+
+func (g *Graph[N, W]) kahn(lexicographic bool) ([]N, error) {
+	if g.undirected {
+		return nil, &UndirectedGraphError{}
+	}
+	inDegree := make(map[N]int, len(g.nodes))
+	for node := range g.nodes {
+		inDegree[node] = 0
+	}
+	for node := range g.nodes {
+		for _, neighbor := range g.Neighbors(node) {
+			inDegree[neighbor.To] += 1
+		}
+	}
+	ready := nodeHeap[N]{}
+	for node, degree := range inDegree {
+		if degree == 0 {
+			ready = append(ready, node)
+		}
+	}
+	if lexicographic {
+		heap.Init(&ready)
+	}
+
+	order := make([]N, 0, len(g.nodes))
+	for len(ready) > 0 {
+		var u N
+		if lexicographic {
+			u = heap.Pop(&ready).(N)
+		} else {
+			u, ready = ready[0], ready[1:]
+		}
+		order = append(order, u)
+		for _, neighbor := range g.Neighbors(u) {
+			inDegree[neighbor.To] -= 1
+			if inDegree[neighbor.To] == 0 {
+				if lexicographic {
+					heap.Push(&ready, neighbor.To)
+				} else {
+					ready = append(ready, neighbor.To)
+				}
+			}
+		}
+	}
+	if len(order) < len(g.nodes) {
+		return nil, &CycleError[N]{Cycle: g.cycleAmong(inDegree)}
+	}
+	return order, nil
+}
+
+// cycleAmong finds a cycle among the nodes Kahn's algorithm could not remove.
+// Each of them still has a predecessor that was not removed either, so walking
+// predecessors must eventually revisit a node.
+func (g *Graph[N, W]) cycleAmong(inDegree map[N]int) []N {
+	remaining := []N{}
+	for node, degree := range inDegree {
+		if degree > 0 {
+			remaining = append(remaining, node)
+		}
+	}
+	sortNodes(remaining)
+	pred := map[N]N{}
+	for _, u := range remaining {
+		for _, neighbor := range g.sortedNeighbors(u) {
+			_, ok := pred[neighbor.To]
+			if !ok && inDegree[neighbor.To] > 0 {
+				pred[neighbor.To] = u
+			}
+		}
+	}
+
+	position := map[N]int{}
+	walk := []N{}
+	for node := remaining[0]; ; node = pred[node] {
+		i, ok := position[node]
+		if ok {
+			walk = walk[i:]
+			break
+		}
+		position[node] = len(walk)
+		walk = append(walk, node)
+	}
+	for i, j := 0, len(walk)-1; i < j; i, j = i+1, j-1 {
+		walk[i], walk[j] = walk[j], walk[i]
+	}
+	return walk
+}
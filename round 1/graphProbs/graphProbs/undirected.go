@@ -0,0 +1,173 @@
+package graphProbs
+
+// undirectedNeighbors returns the distinct nodes adjacent to u in either
+// direction, ignoring self loops, i.e. its neighbors in the underlying simple
+// undirected graph.
+func (g *Graph[N, W]) undirectedNeighbors(u N) []N {
+	seen := map[N]bool{u: true}
+	neighbors := []N{}
+	for _, assocs := range []map[N]W{g.adjacencyMatrix[u], g.reverseAdjacencyMatrix[u]} {
+		for v := range assocs {
+			if !seen[v] {
+				seen[v] = true
+				neighbors = append(neighbors, v)
+			}
+		}
+	}
+	sortNodes(neighbors)
+	return neighbors
+}
+
+// ConnectedComponents groups the nodes of an undirected graph, for a directed
+// graph these are its weakly connected components. Ids follow the smallest
+// node of each component.
+func (g *Graph[N, W]) ConnectedComponents() *Components[N] {
+	components := &Components[N]{Of: map[N]ComponentId{}, Members: [][]N{}}
+	for _, root := range g.sortedNodes() {
+		_, ok := components.Of[root]
+		if ok {
+			continue
+		}
+		id := len(components.Members)
+		members := []N{root}
+		components.Of[root] = id
+		for i := 0; i < len(members); i++ {
+			for _, v := range g.undirectedNeighbors(members[i]) {
+				_, ok := components.Of[v]
+				if !ok {
+					components.Of[v] = id
+					members = append(members, v)
+				}
+			}
+		}
+		sortNodes(members)
+		components.Members = append(components.Members, members)
+	}
+	return components
+}
+
+type lowLinkFrame[N Ordered] struct {
+	node      N
+	parent    *N
+	neighbors []N
+	next      int
+	children  int
+}
+
+// lowLinks runs an iterative depth first search over the underlying simple
+// undirected graph, reporting every tree edge parent -> child once the child
+// is finished, together with the discovery time of the parent and the lowest
+// discovery time reachable from the child's subtree through one back edge.
+func (g *Graph[N, W]) lowLinks(visitTreeEdge func(parent N, child N, parentDisc int, childLow int), visitRoot func(root N, children int)) {
+	disc := map[N]int{}
+	low := map[N]int{}
+	for _, root := range g.sortedNodes() {
+		_, ok := disc[root]
+		if ok {
+			continue
+		}
+		disc[root] = len(disc)
+		low[root] = disc[root]
+		frames := []lowLinkFrame[N]{{node: root, neighbors: g.undirectedNeighbors(root)}}
+		for len(frames) > 0 {
+			frame := &frames[len(frames)-1]
+			if frame.next < len(frame.neighbors) {
+				v := frame.neighbors[frame.next]
+				frame.next++
+				if frame.parent != nil && v == *frame.parent {
+					continue
+				}
+				dv, ok := disc[v]
+				if ok {
+					if dv < low[frame.node] {
+						low[frame.node] = dv
+					}
+					continue
+				}
+				disc[v] = len(disc)
+				low[v] = disc[v]
+				frame.children++
+				parent := frame.node
+				frames = append(frames, lowLinkFrame[N]{node: v, parent: &parent, neighbors: g.undirectedNeighbors(v)})
+				continue
+			}
+			frames = frames[:len(frames)-1]
+			if frame.parent == nil {
+				visitRoot(frame.node, frame.children)
+				continue
+			}
+			parent := *frame.parent
+			if low[frame.node] < low[parent] {
+				low[parent] = low[frame.node]
+			}
+			visitTreeEdge(parent, frame.node, disc[parent], low[frame.node])
+		}
+	}
+}
+
+// Bridges returns the edges whose removal disconnects their endpoints, for
+// directed graphs the edge directions are ignored. A pair joined by more than
+// one edge, parallel ones of a multigraph or u -> v and v -> u of a directed
+// graph, has no bridge, as every edge has a spare.
+func (g *Graph[N, W]) Bridges() []Edge[N, W] {
+	bridges := []Edge[N, W]{}
+	g.lowLinks(func(parent N, child N, parentDisc int, childLow int) {
+		if childLow <= parentDisc {
+			return
+		}
+		if g.multi {
+			joining := g.parallelEdges[parent][child]
+			if !g.undirected {
+				joining = append(append([]Edge[N, W]{}, joining...), g.parallelEdges[child][parent]...)
+			}
+			if len(joining) == 1 {
+				bridges = append(bridges, joining[0])
+			}
+			return
+		}
+		frm, to := parent, child
+		if to < frm {
+			frm, to = to, frm
+		}
+		wt, ok := g.adjacencyMatrix[frm][to]
+		_, reverse := g.adjacencyMatrix[to][frm]
+		if !g.undirected && ok && reverse {
+			return
+		}
+		if !ok {
+			frm, to = to, frm
+			wt = g.adjacencyMatrix[frm][to]
+		}
+		bridges = append(bridges, Edge[N, W]{Frm: frm, To: to, Wt: wt})
+	}, func(N, int) {})
+	sortEdges(bridges)
+	return bridges
+}
+
+// ArticulationPoints returns the nodes whose removal disconnects the rest of
+// their component, for directed graphs the edge directions are ignored.
+func (g *Graph[N, W]) ArticulationPoints() []N {
+	points := map[N]bool{}
+	roots := map[N]bool{}
+	g.lowLinks(func(parent N, child N, parentDisc int, childLow int) {
+		if childLow >= parentDisc {
+			points[parent] = true
+		}
+	}, func(root N, children int) {
+		roots[root] = children > 1
+	})
+	articulationPoints := []N{}
+	for node := range points {
+		isRoot, ok := roots[node]
+		if !ok || isRoot {
+			articulationPoints = append(articulationPoints, node)
+		}
+	}
+	for root, isPoint := range roots {
+		if isPoint && !points[root] {
+			articulationPoints = append(articulationPoints, root)
+		}
+	}
+	sortNodes(articulationPoints)
+	return articulationPoints
+}
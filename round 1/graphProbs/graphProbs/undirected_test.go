@@ -0,0 +1,61 @@
+package graphProbs
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func undirectedEdges(pairs ...string) []StringEdge {
+	edges := make([]StringEdge, len(pairs))
+	for i, pair := range pairs {
+		edges[i] = StringEdge{Frm: pair[:1], To: pair[1:], Wt: 1}
+	}
+	return edges
+}
+
+func TestUndirectedStructure(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		g          StringGraph
+		components string
+		bridges    string
+		points     string
+	}{
+		{"tree", MkUndirectedGraph(undirectedEdges("ab", "ac", "bd", "be", "gh"), []Node{"f"}), "abcde f gh", "ab ac bd be gh", "a b"},
+		{"cycle", MkUndirectedGraph(undirectedEdges("ab", "bc", "cd", "da"), nil), "abcd", "", ""},
+		{"two blocks sharing a node", MkUndirectedGraph(undirectedEdges("ab", "bc", "ca", "cd", "de", "ec"), nil), "abcde", "", "c"},
+		{"blocks joined by a bridge", MkUndirectedGraph(undirectedEdges("ab", "bc", "ca", "cd", "de", "ef", "fd"), nil), "abcdef", "cd", "c d"},
+		{"root with several children", MkUndirectedGraph(undirectedEdges("ab", "ac", "ad", "cd"), nil), "abcd", "ab", "a"},
+		{"root with a single child", MkUndirectedGraph(undirectedEdges("ab", "bc"), nil), "abc", "ab bc", "b"},
+		{"self loop", MkUndirectedGraph(undirectedEdges("aa", "ab"), nil), "ab", "ab", ""},
+	} {
+		components := tc.g.ConnectedComponents()
+		groups := []string{}
+		for id, members := range components.Members {
+			groups = append(groups, strings.Join(members, ""))
+			for _, node := range members {
+				if components.Of[node] != id {
+					t.Errorf("%s: %s is listed in component %d but mapped to %d", tc.name, node, id, components.Of[node])
+				}
+			}
+		}
+		bridges := []string{}
+		for _, edge := range tc.g.Bridges() {
+			bridges = append(bridges, edge.Frm+edge.To)
+		}
+		for _, check := range []struct {
+			what     string
+			expected string
+			got      []string
+		}{
+			{"components", tc.components, groups},
+			{"bridges", tc.bridges, bridges},
+			{"articulation points", tc.points, tc.g.ArticulationPoints()},
+		} {
+			if strings.Join(check.got, " ") != check.expected {
+				t.Errorf("%s: expected %s %q, got %q", tc.name, check.what, check.expected, fmt.Sprint(check.got))
+			}
+		}
+	}
+}
@@ -1,218 +1,144 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
 	"graphProbs/graphProbs"
-	"strconv"
-	"strings"
+	"io"
+	"os"
 )
 
-func handleError(err error) {
-	if err != nil {
-		panic(err)
-	}
-}
+const (
+	exitOk      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
 
-func parseEdge(s string) (*graphProbs.Edge, error) {
-	words := strings.Split(s, " ")
-	if len(words) != 2 {
-		return nil, errors.New(fmt.Sprintf("Unable to parse %s to an edge\n", s))
-	}
-	return &graphProbs.Edge{Frm: words[0], To: words[1]}, nil
+type config struct {
+	inputFilePath string
+	format        string
+	batch         bool
+	undirected    bool
+	multi         bool
 }
 
-func parseWeightedEdge(s string) (*graphProbs.Edge, error) {
-	words := strings.Split(s, " ")
-	err := errors.New(fmt.Sprintf("Unable to parse %s to an edge\n", s))
-	if len(words) != 3 {
-		return nil, err
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: graphProbs <command> [flags] [inputFilePath]")
+	fmt.Fprintln(w, "\nThe input is read from inputFilePath, or stdin when it is omitted or -.")
+	fmt.Fprintln(w, "\ncommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.description)
 	}
-	wt, e := strconv.Atoi(words[2])
-	if e != nil {
-		return nil, err
-	}
-	return &graphProbs.Edge{Frm: words[0], To: words[1], Wt: uint(wt)}, nil
-}
-
-type simpleGraphInput struct {
-	g         graphProbs.Graph
-	follower  graphProbs.Node
-	following graphProbs.Node
+	fmt.Fprintf(w, "  %-10s %s\n", "generate", generateDescription)
+	fmt.Fprintln(w, "\nRun graphProbs <command> -h for the flags of a command.")
 }
 
-func parseSimpleGraphInput(input string) (*simpleGraphInput, error) {
-	lines := strings.Split(input, "\n")
-	if len(lines) == 0 {
-		return nil, errors.New("Inputed string is empty")
+func readProblem(inputFilePath string, opts graphProbs.ProblemOptions) (*graphProbs.Problem, error) {
+	if inputFilePath == "" || inputFilePath == "-" {
+		return graphProbs.ParseProblem(os.Stdin, opts)
 	}
-
-	numNodes, err := strconv.Atoi(lines[0])
+	file, err := os.Open(inputFilePath)
 	if err != nil {
 		return nil, err
 	}
-	lines = lines[1:]
-	if len(lines) < numNodes {
-		return nil, errors.New(fmt.Sprintf("Not enough nodes provided, expected: %d, found: %d\n", numNodes, len(lines)))
-	}
-	nodes := make([]graphProbs.Node, numNodes)
-	for i := 0; i < numNodes; i += 1 {
-		nodes[i] = lines[i]
-	}
-	lines = lines[numNodes:]
-
-	numEdges, err := strconv.Atoi(lines[0])
+	defer file.Close()
+	problem, err := graphProbs.ParseProblem(file, opts)
 	if err != nil {
-		return nil, err
-	}
-	lines = lines[1:]
-	if len(lines) < numEdges {
-		return nil, errors.New(fmt.Sprintf("Not enough edges provided, expected: %d, found: %d\n", numEdges, len(lines)))
-	}
-	edges := make([]graphProbs.Edge, numEdges)
-	for i := 0; i < numEdges; i += 1 {
-		edge, err := parseEdge(lines[i])
-		if err != nil {
-			return nil, err
-		}
-		edges[i] = *edge
+		return nil, fmt.Errorf("%s: %w", inputFilePath, err)
 	}
-	lines = lines[numEdges:]
-
-	if len(lines) != 2 {
-		return nil, errors.New(fmt.Sprintf("Unable to parse follower and following, expected 2 lines, got %d lines\n", len(lines)))
-	}
-	followerNode := lines[0]
-	followingNode := lines[1]
-
-	g := graphProbs.MkGraph(edges, nodes)
-
-	return &simpleGraphInput{g: g, follower: followerNode, following: followingNode}, nil
+	return problem, nil
 }
 
-func solveFindReachability(input string) {
-	simpleInput, err := parseSimpleGraphInput(input)
-	handleError(err)
-	if simpleInput.g.CanReach(simpleInput.follower, simpleInput.following) {
-		fmt.Println("1")
-	} else {
-		fmt.Println("0")
+func printResult(w io.Writer, cfg config, q graphProbs.Query, res result) error {
+	if cfg.format == "json" {
+		return json.NewEncoder(w).Encode(res)
 	}
-}
-
-type weightedGraphInput struct {
-	g         graphProbs.Graph
-	follower  graphProbs.Node
-	following graphProbs.Node
-}
-
-func parseWeightedGraphInput(input string) (*weightedGraphInput, error) {
-	lines := strings.Split(input, "\n")
-	if len(lines) == 0 {
-		return nil, errors.New("Inputed string is empty")
+	if cfg.batch {
+		fmt.Fprintf(w, "# %s %s\n", q.Follower, q.Following)
+	}
+	for _, line := range res.textLines() {
+		fmt.Fprintln(w, line)
 	}
+	return nil
+}
 
-	numNodes, err := strconv.Atoi(lines[0])
-	if err != nil {
-		return nil, err
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return exitUsage
 	}
-	lines = lines[1:]
-	if len(lines) < numNodes {
-		return nil, errors.New(fmt.Sprintf("Not enough nodes provided, expected: %d, found: %d\n", numNodes, len(lines)))
+	if args[0] == "generate" {
+		return runGenerate(args[1:], stdout, stderr)
 	}
-	nodes := make([]graphProbs.Node, numNodes)
-	for i := 0; i < numNodes; i += 1 {
-		nodes[i] = lines[i]
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == args[0] {
+			cmd = &commands[i]
+		}
 	}
-	lines = lines[numNodes:]
-
-	numEdges, err := strconv.Atoi(lines[0])
+	if cmd == nil {
+		if args[0] != "-h" && args[0] != "-help" && args[0] != "help" {
+			fmt.Fprintf(stderr, "unknown command: %s\n\n", args[0])
+		}
+		usage(stderr)
+		return exitUsage
+	}
+
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	cfg := config{}
+	fs.StringVar(&cfg.format, "format", "text", "output format, text or json")
+	fs.BoolVar(&cfg.batch, "batch", false, "after the edges, read any number of \"follower following\" query lines")
+	fs.BoolVar(&cfg.undirected, "undirected", false, "treat every edge as a mutual relationship")
+	fs.BoolVar(&cfg.multi, "multi", false, "keep repeated edges between the same pair of nodes as parallel edges")
+	getSolver := cmd.setup(fs)
+	err := fs.Parse(args[1:])
 	if err != nil {
-		return nil, err
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOk
+		}
+		return exitUsage
 	}
-	lines = lines[1:]
-	if len(lines) < numEdges {
-		return nil, errors.New(fmt.Sprintf("Not enough edges provided, expected: %d, found: %d\n", numEdges, len(lines)))
+	if cfg.format != "text" && cfg.format != "json" {
+		fmt.Fprintf(stderr, "unknown format: %s\n", cfg.format)
+		return exitUsage
 	}
-	edges := make([]graphProbs.Edge, numEdges)
-	for i := 0; i < numEdges; i += 1 {
-		edge, err := parseWeightedEdge(lines[i])
-		if err != nil {
-			return nil, err
-		}
-		edges[i] = *edge
+	if fs.NArg() > 1 {
+		fmt.Fprintln(stderr, "expected at most one inputFilePath")
+		return exitUsage
 	}
-	lines = lines[numEdges:]
-
-	if len(lines) != 2 {
-		return nil, errors.New(fmt.Sprintf("Unable to parse follower and following, expected 2 lines, got %d lines\n", len(lines)))
+	cfg.inputFilePath = fs.Arg(0)
+	weighted, solve, err := getSolver()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
 	}
-	followerNode := lines[0]
-	followingNode := lines[1]
-
-	g := graphProbs.MkGraph(edges, nodes)
-
-	return &weightedGraphInput{g: g, follower: followerNode, following: followingNode}, nil
-}
 
-func solveShortestTime(input string) {
-	weightedInput, err := parseWeightedGraphInput(input)
-	handleError(err)
-	shortestTime := weightedInput.g.ShortestTime(weightedInput.follower, weightedInput.following)
-	if shortestTime == nil {
-		fmt.Println("nil")
-	} else {
-		fmt.Println(*shortestTime)
+	problem, err := readProblem(cfg.inputFilePath, graphProbs.ProblemOptions{Weighted: weighted, Batch: cfg.batch, Undirected: cfg.undirected, Multi: cfg.multi})
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitFailure
 	}
-}
 
-func solveMinimumNeighborsToBlockToEnsureUnreachability(input string) {
-	simpleInput, err := parseSimpleGraphInput(input)
-	handleError(err)
-	for neighbor := range simpleInput.g.NeighborsToBlockToEnsureUnreachability(simpleInput.follower, simpleInput.following) {
-		fmt.Println(neighbor)
+	status := exitOk
+	for _, q := range problem.Queries {
+		res, err := solve(&problem.Graph, q)
+		if err != nil {
+			fmt.Fprintf(stderr, "%s %s: %s\n", q.Follower, q.Following, err)
+			status = exitFailure
+			continue
+		}
+		err = printResult(stdout, cfg, q, res)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitFailure
+		}
 	}
+	return status
 }
 
 func main() {
-	solveFindReachability(`5
-1
-2
-3
-4
-5
-3
-2 1
-1 5
-1 3
-2
-5`)
-	solveShortestTime(`5
-1
-2
-3
-4
-5
-5
-2 1 1
-1 3 1
-1 5 2
-3 4 1
-4 5 1
-2
-5`)
-	solveMinimumNeighborsToBlockToEnsureUnreachability(`5
-1
-2
-3
-4
-5
-5
-2 1
-1 5
-1 3
-5 2
-4 5
-2
-5`)
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
 }
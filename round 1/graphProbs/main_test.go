@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// batchSample rewrites a sample into batch mode, replacing its follower and
+// following lines by the given queries.
+func batchSample(t *testing.T, sample string, queries ...string) string {
+	data, err := os.ReadFile(filepath.Join("samples", sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	lines = append(lines[:len(lines)-2], queries...)
+	path := filepath.Join(t.TempDir(), sample)
+	err = os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRun(t *testing.T) {
+	for _, tc := range []struct {
+		args   []string
+		status int
+		stdout string
+		stderr string
+	}{
+		{[]string{"reach", "samples/reach.txt"}, exitOk, "1\n", ""},
+		{[]string{"shortest", "samples/shortest.txt"}, exitOk, "3\n", ""},
+		{[]string{"shortest", "-path", "samples/shortest.txt"}, exitOk, "3\n2 -> 1 -> 5\n", ""},
+		{[]string{"block", "samples/block.txt"}, exitOk, "1\n", ""},
+		{[]string{"block", "samples/block_hub.txt"}, exitOk, "3\n4\n5\n", ""},
+		{[]string{"block", "-mode", "mincut", "samples/block.txt"}, exitOk, "1\n", ""},
+		{[]string{"block", "-mode", "mincut", "samples/block_hub.txt"}, exitOk, "2\n", ""},
+		{[]string{"edgecut", "samples/edgecut.txt"}, exitOk, "2 1\n", ""},
+		{[]string{"edgecut", "-weighted", "samples/edgecut_weighted.txt"}, exitOk, "2 1 1\n4 5 1\n", ""},
+		{[]string{"reach", "-h"}, exitOk, "", "-format"},
+
+		{nil, exitUsage, "", "usage: graphProbs"},
+		{[]string{"frobnicate"}, exitUsage, "", "unknown command: frobnicate"},
+		{[]string{"reach", "-nope", "samples/reach.txt"}, exitUsage, "", "-nope"},
+		{[]string{"reach", "-format", "xml", "samples/reach.txt"}, exitUsage, "", "unknown format: xml"},
+		{[]string{"reach", "samples/reach.txt", "samples/block.txt"}, exitUsage, "", "at most one inputFilePath"},
+		{[]string{"block", "-mode", "x", "samples/block.txt"}, exitUsage, "", "unknown mode: x"},
+		{[]string{"generate", "-min-weight", "5", "-max-weight", "3"}, exitUsage, "", "-min-weight"},
+
+		{[]string{"reach", "samples/missing.txt"}, exitFailure, "", "missing.txt"},
+		{[]string{"shortest", "samples/reach.txt"}, exitFailure, "", "samples/reach.txt: line 8, column 1"},
+	} {
+		var stdout, stderr bytes.Buffer
+		status := run(tc.args, &stdout, &stderr)
+		if status != tc.status || stdout.String() != tc.stdout || !strings.Contains(stderr.String(), tc.stderr) {
+			t.Errorf("%v: expected status %d, stdout %q and stderr containing %q, got %d, %q and %q", tc.args, tc.status, tc.stdout, tc.stderr, status, stdout.String(), stderr.String())
+		}
+	}
+}
+
+func TestRunJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"shortest", "-format", "json", "-path", "samples/shortest.txt"}, &stdout, &stderr)
+	if status != exitOk {
+		t.Fatalf("expected status %d, got %d: %s", exitOk, status, stderr.String())
+	}
+	res := shortestResult{}
+	err := json.Unmarshal(stdout.Bytes(), &res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Follower != "2" || res.Following != "5" || res.Time == nil || *res.Time != 3 || strings.Join(res.Path, " ") != "2 1 5" {
+		t.Errorf("unexpected result %s", stdout.String())
+	}
+}
+
+func TestRunBatch(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"shortest", "-batch", batchSample(t, "shortest.txt", "2 5", "5 2", "1 4")}, &stdout, &stderr)
+	expected := "# 2 5\n3\n# 5 2\nnil\n# 1 4\n2\n"
+	if status != exitOk || stdout.String() != expected {
+		t.Errorf("expected status %d and %q, got %d and %q: %s", exitOk, expected, status, stdout.String(), stderr.String())
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	status = run([]string{"reach", "-batch", "-format", "json", batchSample(t, "reach.txt", "2 5", "5 2")}, &stdout, &stderr)
+	decoder := json.NewDecoder(&stdout)
+	results := []reachResult{}
+	for decoder.More() {
+		res := reachResult{}
+		err := decoder.Decode(&res)
+		if err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, res)
+	}
+	if status != exitOk || len(results) != 2 || !results[0].Reachable || results[1].Reachable {
+		t.Errorf("expected 2 reaching 5 but not the other way round, got %d %v: %s", status, results, stderr.String())
+	}
+
+	// A failing query is reported and the remaining ones are still answered.
+	stdout.Reset()
+	stderr.Reset()
+	status = run([]string{"edgecut", "-batch", batchSample(t, "edgecut.txt", "2 2", "2 5")}, &stdout, &stderr)
+	if status != exitFailure || stdout.String() != "# 2 5\n2 1\n" || !strings.Contains(stderr.String(), "2 2: ") {
+		t.Errorf("expected the second query answered and the first reported, got %d, %q and %q", status, stdout.String(), stderr.String())
+	}
+}
+
+func TestRunMinimumVertexCutOfDirectEdge(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"block", "-mode", "mincut", "-batch", batchSample(t, "block.txt", "1 5", "2 5")}, &stdout, &stderr)
+	if status != exitFailure || stdout.String() != "# 2 5\n1\n" || !strings.Contains(stderr.String(), "1 5: no vertex cut exists") {
+		t.Errorf("expected 1 -> 5 to be reported and 2 5 answered, got %d, %q and %q", status, stdout.String(), stderr.String())
+	}
+}
+
+func TestGenerateOutputIsReadable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "generated.txt")
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"generate", "-model", "grid", "-weighted", "-queries", "3", "-o", path}, &stdout, &stderr)
+	if status != exitOk {
+		t.Fatalf("expected status %d, got %d: %s", exitOk, status, stderr.String())
+	}
+	status = run([]string{"shortest", "-batch", path}, &stdout, &stderr)
+	if status != exitOk || strings.Count(stdout.String(), "# ") != 3 {
+		t.Errorf("expected 3 answers, got %d and %q: %s", status, stdout.String(), stderr.String())
+	}
+}
+
+func TestGenerateQueriesHaveDistinctEnds(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "generated.txt")
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"generate", "-n", "5", "-queries", "20", "-seed", "3", "-o", path}, &stdout, &stderr)
+	if status != exitOk {
+		t.Fatalf("expected status %d, got %d: %s", exitOk, status, stderr.String())
+	}
+	status = run([]string{"edgecut", "-batch", path}, &stdout, &stderr)
+	if status != exitOk || strings.Count(stdout.String(), "# ") != 20 {
+		t.Errorf("expected 20 answers, got %d and %q: %s", status, stdout.String(), stderr.String())
+	}
+}
+
+func TestRunShortestPathToItself(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"shortest", "-path", "-batch", batchSample(t, "shortest.txt", "2 2")}, &stdout, &stderr)
+	expected := "# 2 2\n0\n2\n"
+	if status != exitOk || stdout.String() != expected {
+		t.Errorf("expected status %d and %q, got %d and %q: %s", exitOk, expected, status, stdout.String(), stderr.String())
+	}
+}